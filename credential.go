@@ -0,0 +1,133 @@
+package zenity
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// CredentialStore stores the credentials of services.
+type CredentialStore interface {
+	// Load returns the stored credentials of a service, or ErrNoCredentials.
+	// The password is empty if only the username is stored.
+	Load(service string) (usr string, pwd []byte, err error)
+	// Store stores the credentials of a service, replacing existing ones.
+	Store(service, usr string, pwd []byte) error
+	// Delete deletes the stored credentials of a service, if any.
+	Delete(service string) error
+}
+
+// DefaultCredentialStore returns the Secret Service, if available,
+// or else nil.
+//
+// Native stores of Windows and macOS are not supported.
+// A FileCredentialStore is never used by default:
+// to use one, pass it to Credentials.
+func DefaultCredentialStore() CredentialStore {
+	if secretServiceAvailable() {
+		return SecretServiceStore{}
+	}
+	return nil
+}
+
+// RememberCredentials returns an Option to remember
+// the credentials entered for service.
+//
+// If a password is stored for service, it is returned without showing the dialog.
+// Otherwise, a stored username is used as the initial EntryText,
+// and after the dialog the user is offered to remember the password.
+// If storing them fails, the credentials are returned along with the error.
+// Delete the stored credentials if they turn out to be wrong.
+// The stored password is returned as a string, which can't be wiped:
+// use the CredentialStore directly to handle it as bytes.
+//
+// If there is no DefaultCredentialStore, and none is set with Credentials,
+// the dialog returns ErrUnsupported.
+func RememberCredentials(service string) Option {
+	return funcOption(func(o *options) { o.rememberCredentials = &service })
+}
+
+// Credentials returns an Option to set the CredentialStore
+// used by RememberCredentials, instead of DefaultCredentialStore.
+func Credentials(store CredentialStore) Option {
+	return funcOption(func(o *options) { o.credentialStore = store })
+}
+
+// RememberLabels returns an Option to set the question, and the button labels,
+// of the dialog that RememberCredentials shows after the password dialog.
+// They default to "Remember the password for <service>?", "Remember" and "Not Now".
+func RememberLabels(text, remember, notNow string) Option {
+	return funcOption(func(o *options) {
+		o.rememberText = text
+		o.rememberLabel = remember
+		o.notNowLabel = notNow
+	})
+}
+
+// SecretServiceStore is a CredentialStore that uses the freedesktop.org
+// Secret Service, like GNOME Keyring or KWallet (Unix only).
+type SecretServiceStore struct{}
+
+// Load returns the stored credentials of a service, or ErrNoCredentials.
+func (SecretServiceStore) Load(service string) (string, []byte, error) {
+	return secretServiceLoad(service)
+}
+
+// Store stores the credentials of a service, replacing existing ones.
+func (SecretServiceStore) Store(service, usr string, pwd []byte) error {
+	return secretServiceStore(service, usr, pwd)
+}
+
+// Delete deletes the stored credentials of a service, if any.
+func (SecretServiceStore) Delete(service string) error {
+	return secretServiceDelete(service)
+}
+
+// Show the password dialog, unless the credentials are stored,
+// and offer to store them.
+// The password is returned as a string, like Password,
+// so only the copies made here are wiped.
+func rememberedPassword(opts options) (string, string, error) {
+	service := *opts.rememberCredentials
+	store := opts.credentialStore
+	if store == nil {
+		store = DefaultCredentialStore()
+	}
+	if store == nil {
+		return "", "", fmt.Errorf("%w: no credential store", ErrUnsupported)
+	}
+
+	usr, stored, err := store.Load(service)
+	if err == nil && len(stored) > 0 {
+		defer Wipe(stored)
+		if !opts.username {
+			usr = ""
+		}
+		return usr, string(stored), nil
+	}
+	if err == nil && opts.username && opts.entryText == "" {
+		opts.entryText = usr
+	}
+
+	usr, pwd, err := validPassword(opts)
+	if err != nil {
+		return "", "", err
+	}
+
+	ask := generalOptions(opts)
+	ask.okLabel = ptr(cmp.Or(opts.rememberLabel, "Remember"))
+	ask.cancelLabel = ptr(cmp.Or(opts.notNowLabel, "Not Now"))
+	text := cmp.Or(opts.rememberText, fmt.Sprintf("Remember the password for %s?", service))
+	err = message(questionKind, text, ask)
+	switch {
+	case err == nil:
+		secret := []byte(pwd)
+		err = store.Store(service, usr, secret)
+		Wipe(secret)
+	case err == ErrCanceled:
+		err = nil
+		if opts.username {
+			err = store.Store(service, usr, nil)
+		}
+	}
+	return usr, pwd, err
+}
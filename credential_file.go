@@ -0,0 +1,159 @@
+package zenity
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileCredentialStore is a CredentialStore that keeps credentials
+// in encrypted files, one per service.
+//
+// Files are encrypted with AES-GCM, using a random key
+// stored in the same directory, only readable by the user.
+// Since the key is stored next to the data it protects,
+// this is obfuscation, not encryption:
+// it keeps credentials from being read accidentally,
+// but not from anyone, or any program, that can read the directory,
+// including copies of it.
+// Prefer a native store, like the Secret Service.
+type FileCredentialStore struct {
+	Dir string // defaults to $XDG_STATE_HOME/zenity/credentials
+}
+
+func (s FileCredentialStore) dir() (string, error) {
+	if s.Dir != "" {
+		return s.Dir, nil
+	}
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "credentials"), nil
+}
+
+// Load returns the stored credentials of a service, or ErrNoCredentials.
+func (s FileCredentialStore) Load(service string) (string, []byte, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, stateName(service)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil, ErrNoCredentials
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	aead, err := credentialCipher(dir, false)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil, ErrNoCredentials
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	size := aead.NonceSize()
+	if len(data) < size {
+		return "", nil, errors.New("invalid credentials file")
+	}
+	plain, err := aead.Open(nil, data[:size], data[size:], []byte(service))
+	if err != nil {
+		return "", nil, err
+	}
+	defer Wipe(plain)
+	usr, pwd, _ := bytes.Cut(plain, []byte{0})
+	return string(usr), bytes.Clone(pwd), nil
+}
+
+// Store stores the credentials of a service, replacing existing ones.
+func (s FileCredentialStore) Store(service, usr string, pwd []byte) error {
+	dir, err := s.dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	aead, err := credentialCipher(dir, true)
+	if err != nil {
+		return err
+	}
+
+	plain := make([]byte, 0, len(usr)+1+len(pwd))
+	plain = append(plain, usr...)
+	plain = append(plain, 0)
+	plain = append(plain, pwd...)
+	defer Wipe(plain)
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plain)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := aead.Seal(nonce, nonce, plain, []byte(service))
+	return writeFileAtomic(filepath.Join(dir, stateName(service)), bytes.NewReader(data), 0600)
+}
+
+// Delete deletes the stored credentials of a service, if any.
+func (s FileCredentialStore) Delete(service string) error {
+	dir, err := s.dir()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, stateName(service)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Returns the cipher of the credentials in dir, creating its key if needed.
+func credentialCipher(dir string, create bool) (cipher.AEAD, error) {
+	path := filepath.Join(dir, "key")
+	key, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && create {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		err = writeFileExclusive(path, key)
+		if errors.Is(err, fs.ErrExist) {
+			// Lost a race, use the winner's key.
+			key, err = os.ReadFile(path)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer Wipe(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Write a file that does not exist, so that it never appears partially written:
+// data is written to a temporary file, which is then linked into place.
+func writeFileExclusive(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+"*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Link(f.Name(), path)
+}
@@ -0,0 +1,220 @@
+package zenity
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// fakeSecretService implements the Secret Service methods used by zenity.
+type fakeSecretService struct {
+	mtx    sync.Mutex
+	conn   *dbus.Conn
+	items  map[dbus.ObjectPath]*fakeSecretItem
+	next   int
+	prompt int
+	// Send Completed signals without a body.
+	malformed bool
+}
+
+type fakeSecretItem struct {
+	attrs  map[string]string
+	secret []byte
+	locked bool
+}
+
+const fakeCollection = secretPath + "/collection/login"
+
+func (s *fakeSecretService) serve(path dbus.ObjectPath, method string, args []any) ([]any, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	switch method {
+	case secretPrefix + "Service.OpenSession":
+		return []any{dbus.MakeVariant(""), dbus.ObjectPath(secretPath + "/session/1")}, nil
+
+	case secretPrefix + "Session.Close":
+		return nil, nil
+
+	case secretPrefix + "Service.ReadAlias":
+		return []any{dbus.ObjectPath(fakeCollection)}, nil
+
+	case secretPrefix + "Service.SearchItems":
+		unlocked, locked := []dbus.ObjectPath{}, []dbus.ObjectPath{}
+		for _, path := range slices.Sorted(maps.Keys(s.items)) {
+			item := s.items[path]
+			if !matchAttrs(item.attrs, args[0].(map[string]string)) {
+				continue
+			}
+			if item.locked {
+				locked = append(locked, path)
+			} else {
+				unlocked = append(unlocked, path)
+			}
+		}
+		return []any{unlocked, locked}, nil
+
+	case secretPrefix + "Service.Unlock":
+		for _, path := range args[0].([]dbus.ObjectPath) {
+			if item, ok := s.items[path]; ok {
+				item.locked = false
+			}
+		}
+		s.prompt++
+		prompt := dbus.ObjectPath(secretPath + "/prompt/" + strconv.Itoa(s.prompt))
+		return []any{[]dbus.ObjectPath{}, prompt}, nil
+
+	case secretPrefix + "Prompt.Prompt":
+		if s.malformed {
+			return nil, s.conn.Emit(path, secretPrefix+"Prompt.Completed")
+		}
+		err := s.conn.Emit(path, secretPrefix+"Prompt.Completed", false, dbus.MakeVariant(""))
+		return nil, err
+
+	case secretPrefix + "Collection.CreateItem":
+		if path != fakeCollection {
+			return nil, dbus.ErrMsgNoObject
+		}
+		props := args[0].(map[string]dbus.Variant)
+		secret := args[1].([]any)
+		s.next++
+		item := dbus.ObjectPath(fakeCollection + "/" + strconv.Itoa(s.next))
+		s.items[item] = &fakeSecretItem{
+			attrs:  props[secretPrefix+"Item.Attributes"].Value().(map[string]string),
+			secret: secret[2].([]byte),
+		}
+		return []any{item, dbus.ObjectPath("/")}, nil
+
+	case secretPrefix + "Item.GetSecret":
+		item, ok := s.items[path]
+		if !ok || item.locked {
+			return nil, dbus.ErrMsgNoObject
+		}
+		return []any{dbusSecret{args[0].(dbus.ObjectPath), []byte{}, item.secret, "text/plain"}}, nil
+
+	case "org.freedesktop.DBus.Properties.Get":
+		item, ok := s.items[path]
+		if !ok || args[1] != "Attributes" {
+			return nil, dbus.ErrMsgNoObject
+		}
+		return []any{dbus.MakeVariant(item.attrs)}, nil
+
+	case secretPrefix + "Item.Delete":
+		delete(s.items, path)
+		return []any{dbus.ObjectPath("/")}, nil
+	}
+	return nil, dbus.ErrMsgUnknownMethod
+}
+
+func (s *fakeSecretService) count() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return len(s.items)
+}
+
+func (s *fakeSecretService) lock() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, item := range s.items {
+		item.locked = true
+	}
+}
+
+func matchAttrs(attrs, query map[string]string) bool {
+	for k, v := range query {
+		if attrs[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func startFakeSecretService(t *testing.T) *fakeSecretService {
+	service := &fakeSecretService{items: map[dbus.ObjectPath]*fakeSecretItem{}}
+
+	addr := startTestBus(t)
+	service.conn = connectTestBus(t, addr, dbus.WithHandler(testHandler(service.serve)))
+	reply, err := service.conn.RequestName(secretName, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatal("could not own name:", err)
+	}
+
+	bus := secretBus
+	secretBus = func() (*dbus.Conn, error) { return dbus.Connect(addr) }
+	t.Cleanup(func() { secretBus = bus })
+	return service
+}
+
+func TestSecretServiceStore(t *testing.T) {
+	service := startFakeSecretService(t)
+	var store SecretServiceStore
+
+	if !secretServiceAvailable() {
+		t.Fatal("secretServiceAvailable() = false")
+	}
+	if _, _, err := store.Load("example.com"); err != ErrNoCredentials {
+		t.Fatalf("Load() = %v; want %v", err, ErrNoCredentials)
+	}
+
+	if err := store.Store("example.com", "old", []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Store("example.com", "user", []byte("password")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Store("example.org", "other", []byte("other")); err != nil {
+		t.Fatal(err)
+	}
+	if n := service.count(); n != 2 {
+		t.Errorf("Store() kept %d items; want 2", n)
+	}
+
+	service.lock()
+	usr, pwd, err := store.Load("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usr != "user" || string(pwd) != "password" {
+		t.Errorf("Load() = %q, %q; want %q, %q", usr, pwd, "user", "password")
+	}
+
+	if err := store.Delete("example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Load("example.com"); err != ErrNoCredentials {
+		t.Errorf("Load() = %v; want %v", err, ErrNoCredentials)
+	}
+	if n := service.count(); n != 1 {
+		t.Errorf("Delete() kept %d items; want 1", n)
+	}
+
+	service.mtx.Lock()
+	service.malformed = true
+	service.mtx.Unlock()
+	service.lock()
+	if _, _, err := store.Load("example.org"); err == nil {
+		t.Error("Load() accepted a malformed Completed signal")
+	}
+}
+
+func TestSecretServiceStore_unavailable(t *testing.T) {
+	addr := startTestBus(t)
+	bus := secretBus
+	secretBus = func() (*dbus.Conn, error) { return dbus.Connect(addr) }
+	t.Cleanup(func() { secretBus = bus })
+
+	if secretServiceAvailable() {
+		t.Error("secretServiceAvailable() = true")
+	}
+	if store := DefaultCredentialStore(); store != nil {
+		t.Errorf("DefaultCredentialStore() = %v; want nil", store)
+	}
+	if _, _, err := Password(RememberCredentials("example.com")); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Password() = %v; want %v", err, ErrUnsupported)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build windows || darwin
+
+package zenity
+
+func secretServiceAvailable() bool { return false }
+
+func secretServiceLoad(service string) (string, []byte, error) { return "", nil, ErrUnsupported }
+
+func secretServiceStore(service, usr string, pwd []byte) error { return ErrUnsupported }
+
+func secretServiceDelete(service string) error { return ErrUnsupported }
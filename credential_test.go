@@ -0,0 +1,123 @@
+package zenity
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFileCredentialStore(t *testing.T) {
+	t.Parallel()
+	store := FileCredentialStore{Dir: filepath.Join(t.TempDir(), "credentials")}
+
+	if _, _, err := store.Load("example.com"); err != ErrNoCredentials {
+		t.Fatalf("Load() = %v; want %v", err, ErrNoCredentials)
+	}
+	if err := store.Store("example.com", "user", []byte("pass\x00word")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Store("example.org", "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	usr, pwd, err := store.Load("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usr != "user" || string(pwd) != "pass\x00word" {
+		t.Errorf("Load() = %q, %q; want %q, %q", usr, pwd, "user", "pass\x00word")
+	}
+	usr, pwd, err = store.Load("example.org")
+	if err != nil || usr != "" || len(pwd) != 0 {
+		t.Errorf("Load() = %q, %q, %v", usr, pwd, err)
+	}
+
+	// Files are encrypted, and bound to their service.
+	com := filepath.Join(store.Dir, "_example.com")
+	org := filepath.Join(store.Dir, "_example.org")
+	data, err := os.ReadFile(com)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(org, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Load("example.org"); err == nil {
+		t.Error("Load() did not fail for a swapped file")
+	}
+
+	if err := store.Delete("example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Load("example.com"); err != ErrNoCredentials {
+		t.Errorf("Load() = %v; want %v", err, ErrNoCredentials)
+	}
+}
+
+func Test_credentialCipher_race(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	var wg sync.WaitGroup
+	sealed := make([][]byte, 8)
+	for i := range sealed {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			aead, err := credentialCipher(dir, true)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			sealed[i] = aead.Seal(nil, make([]byte, aead.NonceSize()), []byte("secret"), nil)
+		}()
+	}
+	wg.Wait()
+
+	for _, s := range sealed[1:] {
+		if !bytes.Equal(s, sealed[0]) {
+			t.Fatal("credentialCipher() used different keys")
+		}
+	}
+	if tmp, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(tmp) != 0 {
+		t.Errorf("credentialCipher() left %q", tmp)
+	}
+}
+
+type mapCredentialStore map[string][2]string
+
+func (m mapCredentialStore) Load(service string) (string, []byte, error) {
+	if c, ok := m[service]; ok {
+		return c[0], []byte(c[1]), nil
+	}
+	return "", nil, ErrNoCredentials
+}
+
+func (m mapCredentialStore) Store(service, usr string, pwd []byte) error {
+	m[service] = [2]string{usr, string(pwd)}
+	return nil
+}
+
+func (m mapCredentialStore) Delete(service string) error {
+	delete(m, service)
+	return nil
+}
+
+func Test_rememberedPassword(t *testing.T) {
+	t.Parallel()
+	store := mapCredentialStore{"example.com": {"user", "password"}}
+
+	usr, pwd, err := Password(Username(), RememberCredentials("example.com"), Credentials(store))
+	if usr != "user" || pwd != "password" || err != nil {
+		t.Errorf("Password() = %q, %q, %v", usr, pwd, err)
+	}
+	usr, pwd, err = Password(RememberCredentials("example.com"), Credentials(store))
+	if usr != "" || pwd != "password" || err != nil {
+		t.Errorf("Password() = %q, %q, %v", usr, pwd, err)
+	}
+}
@@ -0,0 +1,249 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	secretName   = "org.freedesktop.secrets"
+	secretPath   = "/org/freedesktop/secrets"
+	secretPrefix = "org.freedesktop.Secret."
+	secretSchema = "io.github.ncruces.zenity.Password"
+)
+
+// This is replaced by tests.
+var secretBus = func() (*dbus.Conn, error) { return dbus.ConnectSessionBus() }
+
+// The Secret structure of the Secret Service API.
+type dbusSecret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+type secretService struct {
+	conn    *dbus.Conn
+	obj     dbus.BusObject
+	session dbus.ObjectPath
+}
+
+func openSecretService() (*secretService, error) {
+	conn, err := secretBus()
+	if err != nil {
+		return nil, err
+	}
+	obj := conn.Object(secretName, secretPath)
+
+	// The plain algorithm relies on the security of the session bus.
+	var output dbus.Variant
+	var session dbus.ObjectPath
+	err = obj.Call(secretPrefix+"Service.OpenSession", 0, "plain", dbus.MakeVariant("")).
+		Store(&output, &session)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &secretService{conn: conn, obj: obj, session: session}, nil
+}
+
+func (s *secretService) close() error {
+	s.conn.Object(secretName, s.session).Call(secretPrefix+"Session.Close", 0)
+	return s.conn.Close()
+}
+
+// Find the items of a service, unlocking them if needed.
+func (s *secretService) search(service string) ([]dbus.ObjectPath, error) {
+	attrs := map[string]string{"xdg:schema": secretSchema, "service": service}
+
+	var unlocked, locked []dbus.ObjectPath
+	err := s.obj.Call(secretPrefix+"Service.SearchItems", 0, attrs).Store(&unlocked, &locked)
+	if err != nil {
+		return nil, err
+	}
+	if len(locked) > 0 {
+		if err := s.unlock(locked...); err != nil {
+			return nil, err
+		}
+	}
+	return append(unlocked, locked...), nil
+}
+
+func (s *secretService) unlock(objects ...dbus.ObjectPath) error {
+	var unlocked []dbus.ObjectPath
+	var prompt dbus.ObjectPath
+	err := s.obj.Call(secretPrefix+"Service.Unlock", 0, objects).Store(&unlocked, &prompt)
+	if err != nil {
+		return err
+	}
+	return s.prompt(prompt)
+}
+
+// Show a prompt, if needed, and wait for the user to complete it.
+func (s *secretService) prompt(path dbus.ObjectPath) error {
+	if path == "" || path == "/" {
+		return nil
+	}
+
+	match := []dbus.MatchOption{
+		dbus.WithMatchObjectPath(path),
+		dbus.WithMatchInterface(secretPrefix + "Prompt"),
+		dbus.WithMatchMember("Completed"),
+	}
+	if err := s.conn.AddMatchSignal(match...); err != nil {
+		return err
+	}
+	defer s.conn.RemoveMatchSignal(match...)
+	signals := make(chan *dbus.Signal, 1)
+	s.conn.Signal(signals)
+	defer s.conn.RemoveSignal(signals)
+
+	err := s.conn.Object(secretName, path).Call(secretPrefix+"Prompt.Prompt", 0, "").Err
+	if err != nil {
+		return err
+	}
+	for sig := range signals {
+		if sig.Path != path || sig.Name != secretPrefix+"Prompt.Completed" {
+			continue
+		}
+		if len(sig.Body) == 0 {
+			return errors.New("secret service sent a malformed signal")
+		}
+		if dismissed, _ := sig.Body[0].(bool); dismissed {
+			return ErrCanceled
+		}
+		return nil
+	}
+	return errors.New("secret service disconnected")
+}
+
+// Find the default collection, unlocking it if needed.
+func (s *secretService) collection() (dbus.BusObject, error) {
+	var path dbus.ObjectPath
+	err := s.obj.Call(secretPrefix+"Service.ReadAlias", 0, "default").Store(&path)
+	if err != nil {
+		return nil, err
+	}
+	if path == "/" {
+		return nil, errors.New("no default secret collection")
+	}
+	if err := s.unlock(path); err != nil {
+		return nil, err
+	}
+	return s.conn.Object(secretName, path), nil
+}
+
+func (s *secretService) delete(items []dbus.ObjectPath) error {
+	for _, item := range items {
+		var prompt dbus.ObjectPath
+		err := s.conn.Object(secretName, item).Call(secretPrefix+"Item.Delete", 0).Store(&prompt)
+		if err == nil {
+			err = s.prompt(prompt)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func secretServiceAvailable() bool {
+	s, err := openSecretService()
+	if err != nil {
+		return false
+	}
+	s.close()
+	return true
+}
+
+func secretServiceLoad(service string) (string, []byte, error) {
+	s, err := openSecretService()
+	if err != nil {
+		return "", nil, err
+	}
+	defer s.close()
+
+	items, err := s.search(service)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(items) == 0 {
+		return "", nil, ErrNoCredentials
+	}
+	item := s.conn.Object(secretName, items[0])
+
+	var secret dbusSecret
+	err = item.Call(secretPrefix+"Item.GetSecret", 0, s.session).Store(&secret)
+	if err != nil {
+		return "", nil, err
+	}
+	var attrs map[string]string
+	err = item.StoreProperty(secretPrefix+"Item.Attributes", &attrs)
+	if err != nil {
+		Wipe(secret.Value)
+		return "", nil, err
+	}
+	return attrs["username"], secret.Value, nil
+}
+
+func secretServiceStore(service, usr string, pwd []byte) error {
+	s, err := openSecretService()
+	if err != nil {
+		return err
+	}
+	defer s.close()
+
+	// Items are replaced if their attributes match,
+	// but the username is an attribute, so delete them first.
+	items, err := s.search(service)
+	if err != nil {
+		return err
+	}
+	if err := s.delete(items); err != nil {
+		return err
+	}
+
+	coll, err := s.collection()
+	if err != nil {
+		return err
+	}
+	props := map[string]dbus.Variant{
+		secretPrefix + "Item.Label": dbus.MakeVariant("Password for " + service),
+		secretPrefix + "Item.Attributes": dbus.MakeVariant(map[string]string{
+			"xdg:schema": secretSchema,
+			"service":    service,
+			"username":   usr,
+		}),
+	}
+	secret := dbusSecret{
+		Session:     s.session,
+		Parameters:  []byte{},
+		Value:       pwd,
+		ContentType: "text/plain",
+	}
+
+	var item, prompt dbus.ObjectPath
+	err = coll.Call(secretPrefix+"Collection.CreateItem", 0, props, secret, true).Store(&item, &prompt)
+	if err != nil {
+		return err
+	}
+	return s.prompt(prompt)
+}
+
+func secretServiceDelete(service string) error {
+	s, err := openSecretService()
+	if err != nil {
+		return err
+	}
+	defer s.close()
+
+	items, err := s.search(service)
+	if err != nil {
+		return err
+	}
+	return s.delete(items)
+}
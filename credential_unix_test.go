@@ -0,0 +1,35 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"errors"
+	"testing"
+)
+
+type failingCredentialStore struct{ err error }
+
+func (s failingCredentialStore) Load(string) (string, []byte, error) {
+	return "", nil, ErrNoCredentials
+}
+func (s failingCredentialStore) Store(string, string, []byte) error { return s.err }
+func (s failingCredentialStore) Delete(string) error                { return s.err }
+
+func Test_rememberedPassword_storeError(t *testing.T) {
+	installFakeTool(t, `#!/bin/sh
+for arg; do
+	case "$arg" in
+	--password) echo "user|secret"; exit ;;
+	--question) exit ;;
+	esac
+done
+exit 1
+`)
+
+	want := errors.New("store failed")
+	usr, pwd, err := Password(Username(), RememberCredentials("example.com"),
+		Credentials(failingCredentialStore{want}))
+	if usr != "user" || pwd != "secret" || err != want {
+		t.Errorf("Password() = %q, %q, %v; want %v", usr, pwd, err, want)
+	}
+}
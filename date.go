@@ -1,15 +1,71 @@
 package zenity
 
-import "time"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Calendar displays the calendar dialog.
 //
 // Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
-// WindowIcon, Attach, Modal, DefaultDate.
+// WindowIcon, Attach, Modal, DefaultDate, MinDate, MaxDate, Weekdays.
 //
 // May return: ErrCanceled, ErrExtraButton.
 func Calendar(text string, options ...Option) (time.Time, error) {
-	return calendar(text, applyOptions(options))
+	return validCalendar(text, applyOptions(options))
+}
+
+// DateTime displays the calendar dialog, and then asks for the time of day,
+// like 15:04 or 15:04:05, and the time zone, if TimeZones are given.
+// It returns the date and time in loc, or in time.Local if loc is nil.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, DefaultDate, MinDate, MaxDate, Weekdays,
+// TimeZones, DateTimeLabels.
+//
+// May return: ErrCanceled, ErrExtraButton.
+func DateTime(text string, loc *time.Location, options ...Option) (time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	opts := applyOptions(options)
+	date, err := validCalendar(text, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	ask := generalOptions(opts)
+	ask.okLabel = opts.okLabel
+	ask.cancelLabel = opts.cancelLabel
+	ask.entryText = time.Now().In(loc).Format("15:04")
+	clock, err := prompt(cmp.Or(opts.timeLabel, "Time:"), ask, "a time, like 15:04", parseClock, func(t time.Time) string {
+		return t.Format("15:04:05")
+	}, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	zone := loc
+	if len(opts.timeZones) > 0 {
+		ask.entryText = ""
+		name, err := list(cmp.Or(opts.zoneLabel, "Time zone:"), opts.timeZones, ask)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if name != "" {
+			zone, err = time.LoadLocation(name)
+			if err != nil {
+				return time.Time{}, err
+			}
+		}
+	}
+
+	year, month, day := date.Date()
+	hour, min, sec := clock.Clock()
+	return time.Date(year, month, day, hour, min, sec, 0, zone).In(loc), nil
 }
 
 // DefaultDate returns an Option to set the date.
@@ -18,3 +74,90 @@ func DefaultDate(year int, month time.Month, day int) Option {
 		o.time = ptr(time.Date(year, month, day, 0, 0, 0, 0, time.Local))
 	})
 }
+
+// MinDate returns an Option to set the earliest date that can be selected.
+// If an earlier date is selected, the calendar dialog is shown again.
+func MinDate(year int, month time.Month, day int) Option {
+	return funcOption(func(o *options) {
+		o.minDate = ptr(time.Date(year, month, day, 0, 0, 0, 0, time.Local))
+	})
+}
+
+// MaxDate returns an Option to set the latest date that can be selected.
+// If a later date is selected, the calendar dialog is shown again.
+func MaxDate(year int, month time.Month, day int) Option {
+	return funcOption(func(o *options) {
+		o.maxDate = ptr(time.Date(year, month, day, 0, 0, 0, 0, time.Local))
+	})
+}
+
+// Weekdays returns an Option to only allow weekdays, Monday to Friday, to be selected.
+// If a weekend day is selected, the calendar dialog is shown again.
+func Weekdays() Option {
+	return funcOption(func(o *options) { o.weekdays = true })
+}
+
+// TimeZones returns an Option to choose the time zone of DateTime
+// from a list of IANA time zone names, like Europe/Lisbon.
+func TimeZones(names ...string) Option {
+	return funcOption(func(o *options) { o.timeZones = names })
+}
+
+// DateTimeLabels returns an Option to set the texts of the dialogs that
+// DateTime shows after the calendar, which default to "Time:" and "Time zone:".
+func DateTimeLabels(timeText, zoneText string) Option {
+	return funcOption(func(o *options) {
+		o.timeLabel = timeText
+		o.zoneLabel = zoneText
+	})
+}
+
+// Show the calendar dialog until the date is valid.
+func validCalendar(text string, opts options) (time.Time, error) {
+	msg := text
+	for {
+		date, err := calendar(msg, opts)
+		if err != nil {
+			return date, err
+		}
+		verr := checkDate(opts, date)
+		if verr == nil {
+			return date, nil
+		}
+		opts.time = ptr(date)
+		msg = verr.Error() + "\n" + text
+	}
+}
+
+// Check a date against the MinDate, MaxDate and Weekdays options.
+func checkDate(opts options, date time.Time) error {
+	const layout = "2006-01-02"
+	day := dateOnly(date)
+	if opts.minDate != nil && day.Before(dateOnly(*opts.minDate)) {
+		return fmt.Errorf("date must not be before %s", opts.minDate.Format(layout))
+	}
+	if opts.maxDate != nil && day.After(dateOnly(*opts.maxDate)) {
+		return fmt.Errorf("date must not be after %s", opts.maxDate.Format(layout))
+	}
+	if opts.weekdays {
+		if wd := date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			return errors.New("date must be a weekday")
+		}
+	}
+	return nil
+}
+
+// Returns the date of t, in UTC, so dates in different locations compare.
+func dateOnly(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Parse a time of day, like 15:04 or 15:04:05.
+func parseClock(s string) (time.Time, error) {
+	layout := "15:04"
+	if strings.Count(s, ":") == 2 {
+		layout = "15:04:05"
+	}
+	return time.Parse(layout, s)
+}
@@ -0,0 +1,55 @@
+package zenity
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_checkDate(t *testing.T) {
+	t.Parallel()
+	opts := applyOptions([]Option{
+		MinDate(2006, time.January, 2),
+		MaxDate(2006, time.January, 31),
+		Weekdays(),
+	})
+
+	tests := []struct {
+		date  time.Time
+		valid bool
+	}{
+		{time.Date(2006, time.January, 2, 0, 0, 0, 0, time.Local), true},
+		{time.Date(2006, time.January, 31, 23, 0, 0, 0, time.Local), true},
+		{time.Date(2006, time.January, 2, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2006, time.January, 1, 0, 0, 0, 0, time.Local), false},
+		{time.Date(2006, time.February, 1, 0, 0, 0, 0, time.Local), false},
+		{time.Date(2006, time.January, 7, 0, 0, 0, 0, time.Local), false},
+		{time.Date(2006, time.January, 8, 0, 0, 0, 0, time.Local), false},
+	}
+	for _, tt := range tests {
+		if err := checkDate(opts, tt.date); (err == nil) != tt.valid {
+			t.Errorf("checkDate(%v) = %v; want valid %v", tt.date, err, tt.valid)
+		}
+	}
+}
+
+func Test_parseClock(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		text string
+		want string
+		ok   bool
+	}{
+		{"15:04", "15:04:00", true},
+		{"09:30:15", "09:30:15", true},
+		{"9:30", "09:30:00", true},
+		{"24:00", "", false},
+		{"15:04pm", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, err := parseClock(tt.text)
+		if (err == nil) != tt.ok || (tt.ok && got.Format("15:04:05") != tt.want) {
+			t.Errorf("parseClock(%q) = %v, %v; want %q", tt.text, got, err, tt.want)
+		}
+	}
+}
@@ -17,6 +17,13 @@ func ExampleCalendar() {
 		zenity.DefaultDate(2006, time.January, 1))
 }
 
+func ExampleDateTime() {
+	zenity.DateTime("Schedule the meeting for:", time.UTC,
+		zenity.MinDate(2006, time.January, 1),
+		zenity.Weekdays(),
+		zenity.TimeZones("UTC", "Europe/Lisbon", "America/New_York"))
+}
+
 func TestCalendar_timeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -0,0 +1,81 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Install a fake dialog tool that answers a calendar with each of dates in turn,
+// an entry with clock, and a list with its second row.
+func installFakeDateTool(t *testing.T, clock string, dates ...string) string {
+	dir := installFakeTool(t, `#!/bin/sh
+dir=$(dirname "$0")
+for arg; do
+	case "$arg" in
+	--calendar)
+		printf '%s\n' "$*" >> "$dir/calendar.args"
+		n=$(wc -l < "$dir/calendar.args")
+		sed -n "${n}p" "$dir/dates"
+		exit ;;
+	--entry) echo "`+clock+`"; exit ;;
+	--list) cat > /dev/null; echo 1; exit ;;
+	esac
+done
+exit 1
+`)
+	err := os.WriteFile(filepath.Join(dir, "dates"), []byte(strings.Join(dates, "\n")+"\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCalendar_reprompt(t *testing.T) {
+	dir := installFakeDateTool(t, "", "2006-01-01", "2006-01-07", "2006-01-09")
+
+	got, err := Calendar("Pick a date", MinDate(2006, time.January, 2), Weekdays())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "2006-01-09"; got.Format("2006-01-02") != want {
+		t.Errorf("Calendar() = %v; want %s", got, want)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "calendar.args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(calls) != 3 {
+		t.Fatalf("Calendar() prompted %d times; want 3", len(calls))
+	}
+	if !strings.Contains(calls[1], "date must not be before 2006-01-02") ||
+		!strings.Contains(calls[1], "--day 1") {
+		t.Errorf("Calendar() prompted with %q", calls[1])
+	}
+	if !strings.Contains(calls[2], "date must be a weekday") {
+		t.Errorf("Calendar() prompted with %q", calls[2])
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	installFakeDateTool(t, "10:30:15", "2006-07-03")
+
+	lisbon, err := time.LoadLocation("Europe/Lisbon")
+	if err != nil {
+		t.Skip("skipping:", err)
+	}
+	got, err := DateTime("When?", time.UTC, TimeZones("UTC", "Europe/Lisbon"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2006, time.July, 3, 10, 30, 15, 0, lisbon).In(time.UTC)
+	if !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("DateTime() = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package zenity
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const testBusConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:dir=%DIR%</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+
+// startTestBus starts a private D-Bus daemon, and returns its address.
+func startTestBus(t *testing.T) string {
+	t.Helper()
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("skipping:", err)
+	}
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "bus.conf")
+	err = os.WriteFile(config, []byte(strings.ReplaceAll(testBusConfig, "%DIR%", dir)), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(daemon, "--nofork", "--print-address", "--config-file="+config)
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Skip("skipping:", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	addr, err := bufio.NewReader(out).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(addr)
+}
+
+// connectTestBus connects to a private D-Bus daemon.
+func connectTestBus(t *testing.T, addr string, opts ...dbus.ConnOption) *dbus.Conn {
+	t.Helper()
+	conn, err := dbus.Connect(addr, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// testHandler serves method calls with their raw arguments,
+// including types the standard handler can't decode,
+// like arrays of file descriptors.
+// The method is qualified by its interface.
+type testHandler func(path dbus.ObjectPath, method string, args []any) ([]any, error)
+
+func (h testHandler) LookupObject(path dbus.ObjectPath) (dbus.ServerObject, bool) {
+	return testObject{h, path}, true
+}
+
+type testObject struct {
+	handler testHandler
+	path    dbus.ObjectPath
+}
+
+func (o testObject) LookupInterface(iface string) (dbus.Interface, bool) {
+	return testInterface{o, iface}, true
+}
+
+type testInterface struct {
+	testObject
+	iface string
+}
+
+func (i testInterface) LookupMethod(name string) (dbus.Method, bool) {
+	return testMethod{i, name}, true
+}
+
+type testMethod struct {
+	testInterface
+	name string
+}
+
+func (m testMethod) Call(args ...any) ([]any, error) {
+	return m.handler(m.path, m.iface+"."+m.name, args)
+}
+
+func (m testMethod) DecodeArguments(conn *dbus.Conn, sender string, msg *dbus.Message, args []any) ([]any, error) {
+	return args, nil
+}
+
+func (testMethod) NumArguments() int     { return 0 }
+func (testMethod) NumReturns() int       { return 0 }
+func (testMethod) ArgumentValue(int) any { return nil }
+func (testMethod) ReturnValue(int) any   { return nil }
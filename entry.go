@@ -3,11 +3,32 @@ package zenity
 // Entry displays the text entry dialog.
 //
 // Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
-// WindowIcon, Attach, Modal, EntryText, HideText.
+// WindowIcon, Attach, Modal, EntryText, HideText, Validate, History.
 //
 // May return: ErrCanceled, ErrExtraButton.
 func Entry(text string, options ...Option) (string, error) {
-	return entry(text, applyOptions(options))
+	return validEntry(text, applyOptions(options))
+}
+
+// Show the entry dialog until the text is valid.
+func validEntry(text string, opts options) (string, error) {
+	loadHistory(&opts)
+	prompt := text
+	for {
+		str, err := entry(prompt, opts)
+		if err != nil {
+			return "", err
+		}
+		var verr error
+		if opts.validate != nil {
+			verr = opts.validate(str)
+		}
+		if verr == nil {
+			return str, saveHistory(opts, str)
+		}
+		opts.entryText = str
+		prompt = verr.Error() + "\n" + text
+	}
 }
 
 // EntryText returns an Option to set the entry text.
@@ -7,7 +7,15 @@ import (
 )
 
 func entry(text string, opts options) (string, error) {
-	var data zenutil.Dialog
+	data, err := entryData(text, opts)
+	if err != nil {
+		return "", err
+	}
+	out, err := zenutil.Run(opts.ctx, "dialog", data)
+	return strResult(opts, out, err)
+}
+
+func entryData(text string, opts options) (data zenutil.Dialog, err error) {
 	data.Text = text
 	data.Operation = "displayDialog"
 	data.Options.Title = opts.title
@@ -24,14 +32,12 @@ func entry(text string, opts options) (string, error) {
 	case string:
 		_, err := os.Stat(i)
 		if err != nil {
-			return "", err
+			return data, err
 		}
 		data.IconPath = i
 	case DialogIcon:
 		data.Options.Icon = i.String()
 	}
 	data.SetButtons(getButtons(true, true, opts))
-
-	out, err := zenutil.Run(opts.ctx, "dialog", data)
-	return strResult(opts, out, err)
+	return data, nil
 }
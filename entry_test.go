@@ -17,6 +17,12 @@ func ExampleEntry() {
 		zenity.Title("Add a new entry"))
 }
 
+func ExampleValidate() {
+	zenity.Entry("Enter the server address:",
+		zenity.Title("Connect"),
+		zenity.Validate(zenity.ValidateHostPort))
+}
+
 func TestEntry_timeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -79,3 +85,17 @@ func TestEntry_script(t *testing.T) {
 		})
 	}
 }
+
+func ExampleHistory() {
+	zenity.Entry("Enter the host name:",
+		zenity.Title("Connect"),
+		zenity.History("hosts"))
+}
+
+func ExamplePromptInt() {
+	zenity.PromptInt("How many copies?",
+		zenity.Title("Print"),
+		zenity.PromptMin(1),
+		zenity.PromptMax(100),
+		zenity.PromptDefault(1))
+}
@@ -2,7 +2,14 @@
 
 package zenity
 
-import "github.com/ncruces/zenity/internal/zenutil"
+import (
+	"strings"
+
+	"github.com/ncruces/zenity/internal/zenutil"
+)
+
+// Maximum length of a choice passed as an argument.
+const maxChoiceLen = 1024
 
 func entry(text string, opts options) (string, error) {
 	args := []string{"--entry", "--text", quoteMnemonics(text)}
@@ -16,6 +23,13 @@ func entry(text string, opts options) (string, error) {
 	if opts.hideText {
 		args = append(args, "--hide-text")
 	}
+	// Extra arguments are offered as choices, but ones that look like options
+	// can't be passed, and long ones could exceed the argument size limit.
+	for _, c := range opts.choices[:min(len(opts.choices), maxHistory)] {
+		if !strings.HasPrefix(c, "-") && len(c) <= maxChoiceLen {
+			args = append(args, c)
+		}
+	}
 
 	out, err := zenutil.Run(opts.ctx, args)
 	return strResult(opts, out, err)
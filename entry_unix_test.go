@@ -0,0 +1,44 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEntry_history(t *testing.T) {
+	dir := installFakeTool(t, `#!/bin/sh
+printf '%s\n' "$@" > "$(dirname "$0")/args"
+echo value
+`)
+	// A file where the state directory should be, so saving fails.
+	state := filepath.Join(dir, "state")
+	if err := os.WriteFile(state, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_STATE_HOME", state)
+
+	got, err := Entry("Text", History("key"))
+	if got != "value" || err == nil {
+		t.Errorf("Entry() = %q, %v; want %q and an error", got, err, "value")
+	}
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	long := strings.Repeat("x", maxChoiceLen+1)
+	if err := writeState("history", "key", []byte(`["one","-two","`+long+`"]`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Entry("Text", History("key")); err != nil {
+		t.Fatal(err)
+	}
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(args); !strings.HasSuffix(s, "\none\n") {
+		t.Errorf("Entry() args = %q; want only the choice %q", s, "one")
+	}
+}
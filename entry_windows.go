@@ -8,23 +8,27 @@ import (
 )
 
 func entry(text string, opts options) (string, error) {
-	if opts.title == nil {
-		opts.title = ptr("")
-	}
-	if opts.okLabel == nil {
-		opts.okLabel = ptr("OK")
-	}
-	if opts.cancelLabel == nil {
-		opts.cancelLabel = ptr("Cancel")
-	}
-
 	dlg := &entryDialog{}
 	return dlg.setup(text, opts)
 }
 
+// Like entry, but returns the text as a secret.
+func entrySecret(text string, opts options) ([]byte, error) {
+	dlg := &entryDialog{secret: true}
+	_, err := dlg.setup(text, opts)
+	if err != nil {
+		Wipe(dlg.bytes)
+		return nil, err
+	}
+	return dlg.bytes, nil
+}
+
 type entryDialog struct {
-	out string
-	err error
+	out    string
+	bytes  []byte
+	secret bool
+	combo  bool
+	err    error
 
 	wnd       win.HWND
 	textCtl   win.HWND
@@ -36,6 +40,16 @@ type entryDialog struct {
 }
 
 func (dlg *entryDialog) setup(text string, opts options) (string, error) {
+	if opts.title == nil {
+		opts.title = ptr("")
+	}
+	if opts.okLabel == nil {
+		opts.okLabel = ptr("OK")
+	}
+	if opts.cancelLabel == nil {
+		opts.cancelLabel = ptr("Cancel")
+	}
+
 	owner, _ := opts.attach.(win.HWND)
 	defer setup(owner)()
 	dlg.font = getFont()
@@ -67,14 +81,26 @@ func (dlg *entryDialog) setup(text string, opts options) (string, error) {
 		strptr("STATIC"), strptr(text), _WS_ZEN_LABEL,
 		12, 10, 241, 16, dlg.wnd, 0, instance, nil)
 
-	var flags uint32 = _WS_ZEN_CONTROL | win.ES_AUTOHSCROLL
-	if opts.hideText {
-		flags |= win.ES_PASSWORD
+	if len(opts.choices) > 0 && !opts.hideText {
+		dlg.combo = true
+		dlg.editCtl, _ = win.CreateWindowEx(0,
+			strptr("COMBOBOX"), nil,
+			_WS_ZEN_CONTROL|win.WS_VSCROLL|win.CBS_DROPDOWN|win.CBS_AUTOHSCROLL,
+			12, 30, 241, 160, dlg.wnd, 0, instance, nil)
+		for _, c := range opts.choices {
+			win.SendMessagePointer(dlg.editCtl, win.CB_ADDSTRING, 0, unsafe.Pointer(strptr(c)))
+		}
+		win.SetWindowText(dlg.editCtl, strptr(opts.entryText))
+	} else {
+		var flags uint32 = _WS_ZEN_CONTROL | win.ES_AUTOHSCROLL
+		if opts.hideText {
+			flags |= win.ES_PASSWORD
+		}
+		dlg.editCtl, _ = win.CreateWindowEx(win.WS_EX_CLIENTEDGE,
+			strptr("EDIT"), strptr(opts.entryText),
+			flags,
+			12, 30, 241, 24, dlg.wnd, 0, instance, nil)
 	}
-	dlg.editCtl, _ = win.CreateWindowEx(win.WS_EX_CLIENTEDGE,
-		strptr("EDIT"), strptr(opts.entryText),
-		flags,
-		12, 30, 241, 24, dlg.wnd, 0, instance, nil)
 
 	dlg.okBtn, _ = win.CreateWindowEx(0,
 		strptr("BUTTON"), strptr(quoteAccelerators(*opts.okLabel)),
@@ -95,7 +121,11 @@ func (dlg *entryDialog) setup(text string, opts options) (string, error) {
 	centerWindow(dlg.wnd)
 	win.SetFocus(dlg.editCtl)
 	win.ShowWindow(dlg.wnd, win.SW_NORMAL)
-	win.SendMessage(dlg.editCtl, win.EM_SETSEL, 0, intptr(-1))
+	if dlg.combo {
+		win.SendMessage(dlg.editCtl, win.CB_SETEDITSEL, 0, 0xffff0000)
+	} else {
+		win.SendMessage(dlg.editCtl, win.EM_SETSEL, 0, intptr(-1))
+	}
 
 	if opts.ctx != nil && opts.ctx.Done() != nil {
 		wait := make(chan struct{})
@@ -127,7 +157,12 @@ func (dlg *entryDialog) layout(dpi dpi) {
 	win.SendMessage(dlg.extraBtn, win.WM_SETFONT, font, 1)
 	win.SetWindowPos(dlg.wnd, 0, 0, 0, dpi.scale(281), dpi.scale(141), win.SWP_NOMOVE|win.SWP_NOZORDER)
 	win.SetWindowPos(dlg.textCtl, 0, dpi.scale(12), dpi.scale(10), dpi.scale(241), dpi.scale(16), win.SWP_NOZORDER)
-	win.SetWindowPos(dlg.editCtl, 0, dpi.scale(12), dpi.scale(30), dpi.scale(241), dpi.scale(24), win.SWP_NOZORDER)
+	if dlg.combo {
+		// The height of a combo box includes its drop-down list.
+		win.SetWindowPos(dlg.editCtl, 0, dpi.scale(12), dpi.scale(30), dpi.scale(241), dpi.scale(160), win.SWP_NOZORDER)
+	} else {
+		win.SetWindowPos(dlg.editCtl, 0, dpi.scale(12), dpi.scale(30), dpi.scale(241), dpi.scale(24), win.SWP_NOZORDER)
+	}
 	if dlg.extraBtn == 0 {
 		win.SetWindowPos(dlg.okBtn, 0, dpi.scale(95), dpi.scale(66), dpi.scale(75), dpi.scale(24), win.SWP_NOZORDER)
 		win.SetWindowPos(dlg.cancelBtn, 0, dpi.scale(178), dpi.scale(66), dpi.scale(75), dpi.scale(24), win.SWP_NOZORDER)
@@ -163,7 +198,11 @@ func entryProc(wnd win.HWND, msg uint32, wparam uintptr, lparam *unsafe.Pointer)
 		default:
 			return 1
 		case win.IDOK, win.IDYES:
-			dlg.out = win.GetWindowText(dlg.editCtl)
+			if dlg.secret {
+				dlg.bytes = win.GetWindowTextBytes(dlg.editCtl)
+			} else {
+				dlg.out = win.GetWindowText(dlg.editCtl)
+			}
 		case win.IDCANCEL:
 			dlg.err = ErrCanceled
 		case win.IDNO:
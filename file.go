@@ -1,40 +1,226 @@
 package zenity
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // SelectFile displays the file selection dialog.
 //
 // Valid options: Title, WindowIcon, Attach, Modal, Directory, Filename,
-// ShowHidden, FileFilter(s).
+// ShowHidden, FileFilter(s), EnforceFilters, ValidatePath, RememberLocation,
+// PathStyle, DocumentPortal, Recent, ExpandFilename.
 //
-// May return: ErrCanceled.
+// May return: ErrCanceled, ErrFilterMismatch.
 func SelectFile(options ...Option) (string, error) {
-	return selectFile(applyOptions(options))
+	opts := applyOptions(options)
+	if err := initFileOptions(&opts); err != nil {
+		return "", err
+	}
+	recent := opts.recent
+	for {
+		var name string
+		var err error
+		if recent {
+			name, err = selectRecent(opts)
+			recent = false
+		}
+		if name == "" && err == nil {
+			name, err = selectFile(dialogOptions(opts))
+		}
+		if err == nil {
+			err = enforceFilters(opts, name)
+		}
+		if err != nil {
+			return "", err
+		}
+		if ok, err := validatePaths(&opts, name); !ok {
+			if err != nil {
+				return "", err
+			}
+			continue
+		}
+		list := []string{name}
+		if err := finishPaths(opts, list); err != nil {
+			return "", err
+		}
+		return list[0], nil
+	}
 }
 
 // SelectFileMultiple displays the multiple file selection dialog.
 //
 // Valid options: Title, WindowIcon, Attach, Modal, Directory, Filename,
-// ShowHidden, FileFilter(s).
+// ShowHidden, FileFilter(s), EnforceFilters, ValidatePath, RememberLocation,
+// PathStyle, DocumentPortal, ExpandFilename.
 //
-// May return: ErrCanceled, ErrUnsupported.
+// May return: ErrCanceled, ErrUnsupported, ErrFilterMismatch.
 func SelectFileMultiple(options ...Option) ([]string, error) {
-	return selectFileMultiple(applyOptions(options))
+	opts := applyOptions(options)
+	if err := initFileOptions(&opts); err != nil {
+		return nil, err
+	}
+	for {
+		list, err := selectFileMultiple(dialogOptions(opts))
+		if err == nil {
+			err = enforceFilters(opts, list...)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := validatePaths(&opts, list...); !ok {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := finishPaths(opts, list); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
 }
 
 // SelectFileSave displays the save file selection dialog.
 //
 // Valid options: Title, WindowIcon, Attach, Modal, Filename,
-// ConfirmOverwrite, ConfirmCreate, ShowHidden, FileFilter(s).
+// ConfirmOverwrite, ConfirmCreate, ShowHidden, FileFilter(s), EnforceFilters,
+// AutoExtension, ValidatePath, RememberLocation, PathStyle, DocumentPortal,
+// ExpandFilename.
 //
-// May return: ErrCanceled.
+// May return: ErrCanceled, ErrFilterMismatch.
 func SelectFileSave(options ...Option) (string, error) {
-	return selectFileSave(applyOptions(options))
+	name, _, err := SelectFileSaveFilter(options...)
+	return name, err
+}
+
+// SelectFileSaveFilter displays the save file selection dialog,
+// and returns the file filter that was active when the file was selected.
+//
+// On Unix and macOS, where the dialog does not report it,
+// the active filter is the first one that matches the file,
+// or else the first one (the default).
+// If there are no filters, the zero FileFilter is returned.
+//
+// Valid options: Title, WindowIcon, Attach, Modal, Filename,
+// ConfirmOverwrite, ConfirmCreate, ShowHidden, FileFilter(s), EnforceFilters,
+// AutoExtension, ValidatePath, RememberLocation, PathStyle, DocumentPortal,
+// ExpandFilename.
+//
+// May return: ErrCanceled, ErrFilterMismatch.
+func SelectFileSaveFilter(options ...Option) (string, FileFilter, error) {
+	opts := applyOptions(options)
+	if err := initFileOptions(&opts); err != nil {
+		return "", FileFilter{}, err
+	}
+	for {
+		name, filter, err := saveFile(opts)
+		if err == nil {
+			err = enforceFilters(opts, name)
+		}
+		if err != nil {
+			return "", FileFilter{}, err
+		}
+		if ok, err := validatePaths(&opts, name); !ok {
+			if err != nil {
+				return "", FileFilter{}, err
+			}
+			continue
+		}
+		list := []string{name}
+		if err := finishPaths(opts, list); err != nil {
+			return "", FileFilter{}, err
+		}
+		return list[0], filter, nil
+	}
+}
+
+// Prepare the filename before showing a file selection dialog.
+func initFileOptions(opts *options) error {
+	name, err := ingestPath(*opts, opts.filename)
+	if err != nil {
+		return err
+	}
+	if opts.expandFilename {
+		name = normalizeFilename(name)
+	}
+	opts.filename = name
+	recallLocation(opts)
+	opts.filename = exportDocument(*opts, opts.filename)
+	return nil
+}
+
+// Process the paths selected in a file selection dialog, in place.
+func finishPaths(opts options, paths []string) (err error) {
+	importDocuments(opts, paths)
+	if len(paths) > 0 {
+		rememberLocation(opts, paths[0])
+	}
+	for i, path := range paths {
+		paths[i], err = egestPath(opts, path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Options for a platform file selection dialog.
+// Platforms adapt filters in place, so they get a copy.
+func dialogOptions(opts options) options {
+	opts.fileFilters = opts.fileFilters.clone()
+	return opts
+}
+
+func saveFile(opts options) (string, FileFilter, error) {
+	filters := opts.fileFilters
+	auto := opts.autoExtension && !opts.directory
+
+	for {
+		name, index, err := selectFileSave(dialogOptions(opts))
+		if err != nil {
+			return "", FileFilter{}, err
+		}
+
+		filter := activeFilter(filters, name, index)
+		if !auto || filepath.Ext(name) != "" {
+			return name, filter, nil
+		}
+		name += filter.extension()
+		// The dialog confirmed the name without the extension,
+		// so show it again with the final name, for it to confirm that.
+		if opts.confirmOverwrite && filepath.Ext(name) != "" {
+			if _, err := os.Stat(name); err == nil {
+				opts.filename = name
+				continue
+			}
+		}
+		return name, filter, nil
+	}
+}
+
+// Find the filter at index, or else the first that matches name,
+// or else the first one.
+func activeFilter(filters FileFilters, name string, index int) FileFilter {
+	if 0 <= index && index < len(filters) {
+		return filters[index]
+	}
+	for _, filter := range filters {
+		if filter.Match(name) {
+			return filter
+		}
+	}
+	if len(filters) > 0 {
+		return filters[0]
+	}
+	return FileFilter{}
 }
 
 // Directory returns an Option to activate directory-only selection.
@@ -54,6 +240,16 @@ func ConfirmCreate() Option {
 	return funcOption(func(o *options) { o.confirmCreate = true })
 }
 
+// AutoExtension returns an Option to append an extension to the selected file
+// name if it has none.
+// The extension is the first literal extension (like "*.png")
+// of the active file filter.
+// With ConfirmOverwrite, if a file with the extension exists,
+// the dialog is shown again with that name, so the dialog confirms it.
+func AutoExtension() Option {
+	return funcOption(func(o *options) { o.autoExtension = true })
+}
+
 // ShowHidden returns an Option to show hidden files (Windows and macOS only).
 func ShowHidden() Option {
 	return funcOption(func(o *options) { o.showHidden = true })
@@ -64,10 +260,23 @@ func ShowHidden() Option {
 // You can specify a file name, a directory path, or both.
 // Specifying a file name, makes it the default selected file.
 // Specifying a directory path, makes it the default dialog location.
+//
+// The filename is translated according to PathStyle.
 func Filename(filename string) Option {
 	return funcOption(func(o *options) { o.filename = filename })
 }
 
+// ExpandFilename returns an Option to normalize Filename,
+// consistently across platforms.
+//
+// A leading ~ and environment variables are expanded,
+// and relative paths are resolved against the current directory.
+// References to unset environment variables are left unchanged.
+// Directories are detected whether or not they have a trailing separator.
+func ExpandFilename() Option {
+	return funcOption(func(o *options) { o.expandFilename = true })
+}
+
 // FileFilter is an Option that sets a filename filter.
 //
 // On Windows and macOS filtering is always case-insensitive.
@@ -88,6 +297,28 @@ func (f FileFilter) apply(o *options) {
 	o.fileFilters = append(o.fileFilters, f)
 }
 
+// Match reports whether the name of the file at path matches any of the patterns.
+func (f FileFilter) Match(path string) bool {
+	name := filepath.Base(path)
+	for _, pattern := range f.Patterns {
+		if fnmatch(pattern, name, f.CaseFold) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find the first literal extension in the patterns, or "".
+func (f FileFilter) extension() string {
+	for _, pattern := range f.Patterns {
+		ext, ok := strings.CutPrefix(pattern, "*.")
+		if ok && ext != "" && !strings.ContainsAny(ext, `*?[\/`) {
+			return "." + ext
+		}
+	}
+	return ""
+}
+
 // FileFilters is an Option that sets multiple filename filters.
 type FileFilters []FileFilter
 
@@ -95,6 +326,154 @@ func (f FileFilters) apply(o *options) {
 	o.fileFilters = append(o.fileFilters, f...)
 }
 
+// Match reports whether the name of the file at path matches any of the filters.
+// If there are no filters, any file matches.
+func (f FileFilters) Match(path string) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, filter := range f {
+		if filter.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnforceFilters returns an Option to check that selected files match
+// the file filters.
+// Since users may be able to select, or type, the name of a file
+// that does not match, this returns ErrFilterMismatch if they do.
+//
+// On macOS, filters with uniform type identifiers accept any file,
+// since they can't be checked by name.
+func EnforceFilters() Option {
+	return funcOption(func(o *options) { o.enforceFilters = true })
+}
+
+func enforceFilters(opts options, paths ...string) error {
+	if !opts.enforceFilters || opts.directory {
+		return nil
+	}
+	filters := opts.fileFilters.clone()
+	// Windows and macOS always filter case-insensitively.
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		for i := range filters {
+			filters[i].CaseFold = true
+		}
+	}
+	// Uniform type identifiers can't be matched by name,
+	// but the dialog only allows files that conform to them.
+	if runtime.GOOS == "darwin" {
+		for i := range filters {
+			if slices.ContainsFunc(filters[i].Patterns, isLikelyTypeIdentifier) {
+				filters[i].Patterns = []string{"*"}
+			}
+		}
+	}
+	for _, path := range paths {
+		if !filters.Match(path) {
+			return fmt.Errorf("%w: %s", ErrFilterMismatch, path)
+		}
+	}
+	return nil
+}
+
+// Copy filters, so they can be modified in place.
+func (f FileFilters) clone() FileFilters {
+	if f == nil {
+		return nil
+	}
+	res := make(FileFilters, len(f))
+	for i, filter := range f {
+		res[i] = filter
+		res[i].Patterns = slices.Clone(filter.Patterns)
+	}
+	return res
+}
+
+// ValidatePath returns an Option to validate selected paths.
+// If validate returns an error for a path, the error is shown to the user,
+// and the dialog is shown again at that path, until a valid selection is made,
+// or the dialog is canceled.
+func ValidatePath(validate func(path string) error) Option {
+	return funcOption(func(o *options) { o.validatePath = validate })
+}
+
+// Validate paths, showing the first error.
+// Returns false if the dialog should be shown again,
+// unless showing the error failed.
+func validatePaths(opts *options, paths ...string) (bool, error) {
+	if opts.validatePath == nil {
+		return true, nil
+	}
+	for _, path := range paths {
+		if verr := opts.validatePath(path); verr != nil {
+			err := message(errorKind, verr.Error(), generalOptions(*opts))
+			if err == ErrCanceled || err == ErrExtraButton {
+				err = nil
+			}
+			opts.filename = path
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// RememberLocation returns an Option to remember the location of the last
+// selection made with the same key, and start there the next time.
+//
+// The directory of the remembered location replaces the directory of Filename.
+// Locations are stored in $XDG_STATE_HOME/zenity.
+func RememberLocation(key string) Option {
+	return funcOption(func(o *options) { o.rememberLocation = &key })
+}
+
+// DocumentPortal returns an Option to translate paths
+// with the Flatpak document portal (Linux only).
+//
+// Inside a sandbox, selected files have document portal paths,
+// like /run/user/1000/doc/XXXXXXXX/file.txt;
+// these are resolved to host paths, where permitted.
+// Conversely, the host path set with Filename is exported to the sandbox.
+func DocumentPortal() Option {
+	return funcOption(func(o *options) { o.documentPortal = true })
+}
+
+func recallLocation(opts *options) {
+	if opts.rememberLocation == nil {
+		return
+	}
+	data, err := readState("location", *opts.rememberLocation)
+	if err != nil {
+		return
+	}
+	dir := string(data)
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return
+	}
+	_, name, _ := splitDirAndName(opts.filename)
+	if name == "" {
+		opts.filename = dir + string(filepath.Separator)
+	} else {
+		opts.filename = filepath.Join(dir, name)
+	}
+}
+
+func rememberLocation(opts options, path string) {
+	if opts.rememberLocation == nil {
+		return
+	}
+	dir := path
+	if !opts.directory {
+		dir = filepath.Dir(path)
+	}
+	dir, err := filepath.Abs(dir)
+	if err == nil {
+		writeState("location", *opts.rememberLocation, []byte(dir))
+	}
+}
+
 // Windows patterns need a name.
 func (f FileFilters) name() {
 	for i, filter := range f {
@@ -274,6 +653,145 @@ func removeClasses(pattern string) string {
 	}
 }
 
+// Match name against an fnmatch pattern, with escaping.
+// Wildcards are matched with backtracking, restarting after the last star.
+func fnmatch(pattern, name string, fold bool) bool {
+	var px, nx int
+	var starPx, starNx = -1, -1
+	for px < len(pattern) || nx < len(name) {
+		if px < len(pattern) {
+			switch pattern[px] {
+			case '*':
+				starPx, starNx = px, nx
+				px++
+				continue
+			case '?':
+				if nx < len(name) {
+					_, w := utf8.DecodeRuneInString(name[nx:])
+					px++
+					nx += w
+					continue
+				}
+			case '[':
+				if end := classEnd(pattern, px); end > 0 {
+					if nx < len(name) {
+						r, w := utf8.DecodeRuneInString(name[nx:])
+						if matchClass(pattern[px+1:end-1], r, fold) {
+							px = end
+							nx += w
+							continue
+						}
+					}
+					break
+				}
+				fallthrough
+			default:
+				p, pw := utf8.DecodeRuneInString(pattern[px:])
+				if p == '\\' && px+pw < len(pattern) {
+					px += pw
+					p, pw = utf8.DecodeRuneInString(pattern[px:])
+				}
+				if nx < len(name) {
+					r, w := utf8.DecodeRuneInString(name[nx:])
+					if equalRune(p, r, fold) {
+						px += pw
+						nx += w
+						continue
+					}
+				}
+			}
+		}
+		// Mismatch: have the last star consume one more rune.
+		if starPx >= 0 && starNx < len(name) {
+			_, w := utf8.DecodeRuneInString(name[starNx:])
+			starNx += w
+			px, nx = starPx+1, starNx
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// Find the end of the character class that starts at start, or -1.
+// A closing bracket right after the opening bracket (or its negation)
+// is part of the class.
+func classEnd(pattern string, start int) int {
+	i := start + 1
+	if i < len(pattern) && pattern[i] == '!' {
+		i++
+	}
+	first := i
+	var escape bool
+	for ; i < len(pattern); i++ {
+		switch b := pattern[i]; {
+		case escape:
+			escape = false
+		case b == '\\':
+			escape = true
+		case b == ']' && i > first:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// Match a rune against the body of a character class (without brackets).
+func matchClass(class string, r rune, fold bool) bool {
+	negate := strings.HasPrefix(class, "!")
+	if negate {
+		class = class[1:]
+	}
+
+	next := func() (rune, bool) {
+		if class == "" {
+			return 0, false
+		}
+		c, w := utf8.DecodeRuneInString(class)
+		if c == '\\' && len(class) > w {
+			class = class[w:]
+			c, w = utf8.DecodeRuneInString(class)
+		}
+		class = class[w:]
+		return c, true
+	}
+
+	var match bool
+	for {
+		lo, ok := next()
+		if !ok {
+			break
+		}
+		hi := lo
+		if len(class) > 1 && class[0] == '-' {
+			class = class[1:]
+			hi, _ = next()
+		}
+		if inRange(lo, hi, r, fold) {
+			match = true
+		}
+	}
+	return match != negate
+}
+
+func inRange(lo, hi, r rune, fold bool) bool {
+	if lo <= r && r <= hi {
+		return true
+	}
+	if fold {
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if lo <= f && f <= hi {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equalRune(p, r rune, fold bool) bool {
+	return inRange(p, p, r, fold)
+}
+
 // Find a character class in the pattern.
 func findClass(pattern string) (start, end int) {
 	start = -1
@@ -325,6 +843,64 @@ func isUniformTypeIdentifier(pattern string) bool {
 	return true
 }
 
+// Reports whether a pattern is likely a uniform type identifier,
+// like public.image or com.adobe.pdf, rather than a literal file name,
+// like main.go or README.md, which isUniformTypeIdentifier also accepts.
+func isLikelyTypeIdentifier(pattern string) bool {
+	if !isUniformTypeIdentifier(pattern) {
+		return false
+	}
+	labels := strings.Split(pattern, ".")
+	switch labels[0] {
+	case "public", "dyn":
+		return true
+	case "com", "org", "net", "io", "edu", "gov":
+		return len(labels) >= 3
+	}
+	return false
+}
+
+// Matches $NAME and ${NAME} references to environment variables.
+var envReference = regexp.MustCompile(`\$(?:[A-Za-z_][A-Za-z0-9_]*|\{[^{}]*\})`)
+
+// Normalize a filename: expand ~ and environment variables,
+// make it absolute, and add a trailing separator to directories.
+// References that can't be expanded are left unchanged.
+func normalizeFilename(filename string) string {
+	if filename == "" {
+		return ""
+	}
+
+	filename = envReference.ReplaceAllStringFunc(filename, func(ref string) string {
+		key := strings.TrimSuffix(strings.TrimPrefix(ref[1:], "{"), "}")
+		if val, ok := os.LookupEnv(key); ok && key != "" {
+			return val
+		}
+		return ref
+	})
+	if rest, ok := strings.CutPrefix(filename, "~"); ok && (rest == "" || os.IsPathSeparator(rest[0])) {
+		if home, err := os.UserHomeDir(); err == nil {
+			filename = home + rest
+		}
+	}
+	if filename == "" {
+		return ""
+	}
+
+	dir := os.IsPathSeparator(filename[len(filename)-1])
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+	if !dir {
+		fi, err := os.Stat(filename)
+		dir = err == nil && fi.IsDir()
+	}
+	if dir && !os.IsPathSeparator(filename[len(filename)-1]) {
+		filename += string(filepath.Separator)
+	}
+	return filename
+}
+
 func splitDirAndName(path string) (dir, name string, err error) {
 	if path == "" {
 		return "", "", nil
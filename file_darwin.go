@@ -63,10 +63,10 @@ func selectFileMultiple(opts options) (list []string, err error) {
 	}
 
 	out, err := zenutil.Run(opts.ctx, "file", data)
-	return lstResult(opts, out, err)
+	return lstResult(opts, validPath, out, err)
 }
 
-func selectFileSave(opts options) (name string, err error) {
+func selectFileSave(opts options) (name string, filter int, err error) {
 	var data zenutil.File
 	data.Options.Prompt = opts.title
 	data.Options.Invisibles = opts.showHidden
@@ -75,7 +75,7 @@ func selectFileSave(opts options) (name string, err error) {
 		data.Options.Location, err = filepath.Abs(data.Options.Location)
 	}
 	if err != nil {
-		return "", err
+		return "", -1, err
 	}
 	if opts.attach != nil {
 		data.Application = opts.attach
@@ -91,5 +91,6 @@ func selectFileSave(opts options) (name string, err error) {
 	}
 
 	out, err := zenutil.Run(opts.ctx, "file", data)
-	return strResult(opts, out, err)
+	name, err = strResult(opts, out, err)
+	return name, -1, err
 }
@@ -1,7 +1,10 @@
 package zenity
 
 import (
+	"errors"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"testing"
 )
 
@@ -116,3 +119,207 @@ func TestFileFilters_types(t *testing.T) {
 		}
 	}
 }
+
+func TestFileFilter_Match(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		name    string
+		fold    bool
+		want    bool
+	}{
+		{``, `a`, false, false},
+		{`*`, `abc`, false, true},
+		{`*.png`, `image.png`, false, true},
+		{`*.png`, `image.PNG`, false, false},
+		{`*.png`, `image.PNG`, true, true},
+		{`*.png`, `image.png.txt`, false, false},
+		{`*.pn?`, `image.pnx`, false, true},
+		{`*.pn?`, `image.pn`, false, false},
+		{`a*b*c`, `aXbYbZc`, false, true},
+		{`a*b*c`, `aXbYbZ`, false, false},
+		{`*.[Pp][Nn][Gg]`, `image.PnG`, false, true},
+		{`*.[a-c]`, `file.b`, false, true},
+		{`*.[a-c]`, `file.B`, false, false},
+		{`*.[a-c]`, `file.B`, true, true},
+		{`*.[!a-c]`, `file.b`, false, false},
+		{`*.[!a-c]`, `file.d`, false, true},
+		{`*.[]]`, `file.]`, false, true},
+		{`*.[!]]`, `file.]`, false, false},
+		{`*.[\]]`, `file.]`, false, true},
+		{`*.[-a]`, `file.-`, false, true},
+		{`*.[PNG`, `file.[PNG`, false, true},
+		{`\*.png`, `*.png`, false, true},
+		{`\*.png`, `a.png`, false, false},
+		{`\?`, `?`, false, true},
+		{`\?`, `a`, false, false},
+		{`Χρτο.go`, `χρτο.GO`, true, true},
+		{`?.go`, `Χ.go`, false, true},
+	}
+	for i, tt := range tests {
+		filter := FileFilter{Patterns: []string{tt.pattern}, CaseFold: tt.fold}
+		if got := filter.Match(tt.name); got != tt.want {
+			t.Errorf("FileFilter.Match[%d](%q, %q) = %v; want %v", i, tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFileFilters_Match(t *testing.T) {
+	t.Parallel()
+	filters := FileFilters{
+		{"Go files", []string{"*.go"}, false},
+		{"Image files", []string{"*.png", "*.jpg"}, true},
+	}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{filepath.Join("dir", "main.go"), true},
+		{filepath.Join("dir", "main.GO"), false},
+		{filepath.Join("dir.go", "image.JPG"), true},
+		{filepath.Join("dir.go", "main.c"), false},
+	}
+	for i, tt := range tests {
+		if got := filters.Match(tt.path); got != tt.want {
+			t.Errorf("FileFilters.Match[%d](%q) = %v; want %v", i, tt.path, got, tt.want)
+		}
+	}
+	if got := (FileFilters{}).Match("main.c"); !got {
+		t.Errorf("FileFilters.Match() = %v; want true", got)
+	}
+}
+
+func Test_enforceFilters(t *testing.T) {
+	t.Parallel()
+	opts := options{
+		enforceFilters: true,
+		fileFilters:    FileFilters{{"Go files", []string{"*.go"}, false}},
+	}
+
+	if err := enforceFilters(opts, "main.go", "util.go"); err != nil {
+		t.Errorf("enforceFilters() = %v; want nil", err)
+	}
+	if err := enforceFilters(opts, "main.go", "main.c"); !errors.Is(err, ErrFilterMismatch) {
+		t.Errorf("enforceFilters() = %v; want %v", err, ErrFilterMismatch)
+	}
+
+	// Platforms adapt filters in place, which must not affect enforcement.
+	dlg := dialogOptions(opts)
+	dlg.fileFilters.simplify()
+	dlg.fileFilters[0].Patterns[0] = "*"
+	if err := enforceFilters(opts, "main.c"); !errors.Is(err, ErrFilterMismatch) {
+		t.Errorf("enforceFilters() = %v; want %v", err, ErrFilterMismatch)
+	}
+
+	opts.fileFilters = FileFilters{{"Images", []string{"public.image"}, false}}
+	err := enforceFilters(opts, "photo.jpg")
+	if runtime.GOOS == "darwin" && err != nil {
+		t.Errorf("enforceFilters() = %v; want nil", err)
+	}
+
+	opts.directory = true
+	if err := enforceFilters(opts, "dir"); err != nil {
+		t.Errorf("enforceFilters() = %v; want nil", err)
+	}
+}
+
+func Test_isLikelyTypeIdentifier(t *testing.T) {
+	t.Parallel()
+	for pattern, want := range map[string]bool{
+		"public.image":            true,
+		"com.adobe.pdf":           true,
+		"com.apple.property-list": true,
+		"dyn.ah62d4rv4ge80e5pe":   true,
+		"main.go":                 false,
+		"README.md":               false,
+		"archive.tar.gz":          false,
+		"com.go":                  false,
+		"*.png":                   false,
+		"public.[jp]*":            false,
+	} {
+		if got := isLikelyTypeIdentifier(pattern); got != want {
+			t.Errorf("isLikelyTypeIdentifier(%q) = %v; want %v", pattern, got, want)
+		}
+	}
+}
+
+func TestFileFilter_extension(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		data FileFilter
+		want string
+	}{
+		{FileFilter{"", []string{`*.png`}, true}, ".png"},
+		{FileFilter{"", []string{`*.tar.gz`}, true}, ".tar.gz"},
+		{FileFilter{"", []string{`*.[jJ][pP][gG]`, `*.jpeg`}, true}, ".jpeg"},
+		{FileFilter{"", []string{`README*`, `*.*`}, true}, ""},
+		{FileFilter{"", nil, true}, ""},
+	}
+	for i, tt := range tests {
+		if got := tt.data.extension(); got != tt.want {
+			t.Errorf("FileFilter.extension[%d] = %q; want %q", i, got, tt.want)
+		}
+	}
+}
+
+func Test_activeFilter(t *testing.T) {
+	t.Parallel()
+	filters := FileFilters{
+		{"PNG", []string{`*.png`}, true},
+		{"JPEG", []string{`*.jpg`, `*.jpeg`}, true},
+	}
+	tests := []struct {
+		name  string
+		index int
+		want  string
+	}{
+		{"image.jpg", 0, "PNG"},
+		{"image", 1, "JPEG"},
+		{"image.jpeg", -1, "JPEG"},
+		{"image", -1, "PNG"},
+		{"image.gif", -1, "PNG"},
+	}
+	for _, tt := range tests {
+		if got := activeFilter(filters, tt.name, tt.index); got.Name != tt.want {
+			t.Errorf("activeFilter(%q, %d) = %q; want %q", tt.name, tt.index, got.Name, tt.want)
+		}
+	}
+	if got := activeFilter(nil, "image", -1); !reflect.DeepEqual(got, FileFilter{}) {
+		t.Errorf("activeFilter(nil) = %v; want zero", got)
+	}
+}
+
+func TestFileFilters_clone(t *testing.T) {
+	t.Parallel()
+	filters := FileFilters{{"", []string{`*.png`}, true}}
+	clone := filters.clone()
+	clone.casefold()
+	if got := filters[0].Patterns[0]; got != `*.png` {
+		t.Errorf("FileFilters.clone() shares patterns: %q", got)
+	}
+}
+
+func Test_validatePaths(t *testing.T) {
+	t.Parallel()
+	var checked []string
+	opts := applyOptions([]Option{ValidatePath(func(path string) error {
+		checked = append(checked, path)
+		return nil
+	})})
+	if opts.validatePath == nil {
+		t.Fatal("ValidatePath() not applied")
+	}
+
+	ok, err := validatePaths(&opts, "a.txt", "b.txt")
+	if !ok || err != nil {
+		t.Errorf("validatePaths() = %v, %v; want true, nil", ok, err)
+	}
+	if want := []string{"a.txt", "b.txt"}; !reflect.DeepEqual(checked, want) {
+		t.Errorf("validatePaths() checked %q; want %q", checked, want)
+	}
+
+	ok, err = validatePaths(&options{}, "a.txt")
+	if !ok || err != nil {
+		t.Errorf("validatePaths() = %v, %v; want true, nil", ok, err)
+	}
+}
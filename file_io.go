@@ -0,0 +1,127 @@
+package zenity
+
+import (
+	"io"
+	"os"
+	"slices"
+)
+
+// OpenFile displays the file selection dialog,
+// and opens the selected file for reading.
+//
+// With PathStyle, Filename is translated, but files are opened with their
+// native paths.
+//
+// Valid options: Title, WindowIcon, Attach, Modal, Filename,
+// ShowHidden, FileFilter(s), EnforceFilters, ValidatePath, RememberLocation,
+// PathStyle, DocumentPortal, Recent, ShowErrors, ExpandFilename.
+//
+// May return: ErrCanceled, ErrFilterMismatch.
+func OpenFile(options ...Option) (*os.File, error) {
+	opts := applyOptions(options)
+	native, err := nativeOptions(opts, options)
+	if err != nil {
+		return nil, err
+	}
+	name, err := SelectFile(native...)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, showError(opts, err)
+	}
+	return f, nil
+}
+
+// OpenFileMultiple displays the multiple file selection dialog,
+// and opens the selected files for reading.
+//
+// With PathStyle, Filename is translated, but files are opened with their
+// native paths.
+//
+// Valid options: Title, WindowIcon, Attach, Modal, Filename,
+// ShowHidden, FileFilter(s), EnforceFilters, ValidatePath, RememberLocation,
+// PathStyle, DocumentPortal, ShowErrors, ExpandFilename.
+//
+// May return: ErrCanceled, ErrUnsupported, ErrFilterMismatch.
+func OpenFileMultiple(options ...Option) ([]*os.File, error) {
+	opts := applyOptions(options)
+	native, err := nativeOptions(opts, options)
+	if err != nil {
+		return nil, err
+	}
+	list, err := SelectFileMultiple(native...)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*os.File, 0, len(list))
+	for _, name := range list {
+		f, err := os.Open(name)
+		if err != nil {
+			for _, f := range files {
+				f.Close()
+			}
+			return nil, showError(opts, err)
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// SaveFile displays the save file selection dialog,
+// and writes the contents of r to the selected file.
+//
+// The file is written atomically: to a temporary file in the same directory,
+// which is synced, and then renamed to the selected file.
+// The permissions of an existing file are preserved.
+// With PathStyle, Filename and the returned name are translated,
+// but the file is written to its native path.
+//
+// Valid options: Title, WindowIcon, Attach, Modal, Filename,
+// ConfirmOverwrite, ConfirmCreate, ShowHidden, FileFilter(s), EnforceFilters,
+// AutoExtension, ValidatePath, RememberLocation, PathStyle, DocumentPortal,
+// ShowErrors, ExpandFilename.
+//
+// May return: ErrCanceled, ErrFilterMismatch.
+func SaveFile(r io.Reader, options ...Option) (string, error) {
+	opts := applyOptions(options)
+	native, err := nativeOptions(opts, options)
+	if err != nil {
+		return "", err
+	}
+	name, err := SelectFileSave(native...)
+	if err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(name, r, 0666); err != nil {
+		return "", showError(opts, err)
+	}
+	return egestPath(opts, name)
+}
+
+// Returns options that select files with their native paths,
+// starting from the native path of Filename.
+func nativeOptions(opts options, list []Option) ([]Option, error) {
+	name, err := ingestPath(opts, opts.filename)
+	if err != nil {
+		return nil, err
+	}
+	return append(slices.Clip(list), funcOption(func(o *options) {
+		o.filename = name
+		o.pathStyle = nil
+	})), nil
+}
+
+// ShowErrors returns an Option to show errors opening or writing files
+// in an error dialog.
+func ShowErrors() Option {
+	return funcOption(func(o *options) { o.showErrors = true })
+}
+
+func showError(opts options, err error) error {
+	if opts.showErrors {
+		message(errorKind, err.Error(), generalOptions(opts))
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+package zenity
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// FileFilterMIME returns a FileFilter that matches files of the given
+// MIME types, like "application/pdf", or "image/*".
+//
+// On Unix, MIME types are resolved to patterns using the shared MIME-info
+// database (https://specifications.freedesktop.org/shared-mime-info-spec/).
+// Otherwise, or if a type is not found there, a builtin table of common types is used.
+// It returns an error if any of the types is not found.
+//
+// Patterns are matched case-insensitively,
+// unless the database marks them as case-sensitive.
+func FileFilterMIME(name string, types ...string) (FileFilter, error) {
+	globs, err := mimePatterns(mimeGlobs(), types)
+	if err != nil {
+		return FileFilter{}, err
+	}
+	return mimeFilter(name, globs), nil
+}
+
+// mimeGlob is a pattern from the shared MIME-info database.
+type mimeGlob struct {
+	mime          string
+	pattern       string
+	caseSensitive bool
+}
+
+// Returns a filter with the patterns of globs.
+// A filter can't mix case-sensitive and case-insensitive patterns,
+// so if there are case-sensitive patterns, the others are folded.
+func mimeFilter(name string, globs []mimeGlob) FileFilter {
+	res := FileFilter{Name: name, CaseFold: true}
+	if slices.ContainsFunc(globs, func(g mimeGlob) bool { return g.caseSensitive }) {
+		res.CaseFold = false
+	}
+	for _, g := range globs {
+		pattern := g.pattern
+		if !res.CaseFold && !g.caseSensitive {
+			fold := FileFilters{{Patterns: []string{pattern}, CaseFold: true}}
+			fold.casefold()
+			pattern = fold[0].Patterns[0]
+		}
+		res.Patterns = append(res.Patterns, pattern)
+	}
+	return res
+}
+
+var mimeGlobs = sync.OnceValue(func() []mimeGlob {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	home := os.Getenv("XDG_DATA_HOME")
+	if home == "" {
+		if dir, err := os.UserHomeDir(); err == nil {
+			home = filepath.Join(dir, ".local/share")
+		}
+	}
+	dirs := os.Getenv("XDG_DATA_DIRS")
+	if dirs == "" {
+		dirs = "/usr/local/share/:/usr/share/"
+	}
+
+	var globs []mimeGlob
+	for _, dir := range append([]string{home}, filepath.SplitList(dirs)...) {
+		if dir == "" {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, "mime/globs2"))
+		if err != nil {
+			continue
+		}
+		globs = append(globs, parseMIMEGlobs(f)...)
+		f.Close()
+	}
+	return globs
+})
+
+// parseMIMEGlobs parses a globs2 file: lines of weight:mime:pattern[:flags].
+func parseMIMEGlobs(r io.Reader) []mimeGlob {
+	var globs []mimeGlob
+	for scanner := bufio.NewScanner(r); scanner.Scan(); {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.SplitN(line, ":", 4)
+		if len(fields) < 3 || fields[2] == "" {
+			continue
+		}
+		glob := mimeGlob{mime: fields[1], pattern: fields[2]}
+		if len(fields) > 3 {
+			glob.caseSensitive = slices.Contains(strings.Split(fields[3], ","), "cs")
+		}
+		globs = append(globs, glob)
+	}
+	return globs
+}
+
+// mimePatterns resolves MIME types, which may use wildcards,
+// to the patterns in globs, or else the builtin table.
+// MIME types are case-insensitive.
+func mimePatterns(globs []mimeGlob, types []string) ([]mimeGlob, error) {
+	var res []mimeGlob
+	add := func(g mimeGlob) {
+		if !slices.ContainsFunc(res, func(r mimeGlob) bool { return r.pattern == g.pattern }) {
+			res = append(res, g)
+		}
+	}
+
+	for _, typ := range types {
+		typ = strings.ToLower(typ)
+
+		var found bool
+		for _, g := range globs {
+			if ok, _ := path.Match(typ, strings.ToLower(g.mime)); ok {
+				add(g)
+				found = true
+			}
+		}
+		if found {
+			continue
+		}
+		for _, g := range builtinMIMEGlobs {
+			if ok, _ := path.Match(typ, g[0]); ok {
+				add(mimeGlob{mime: g[0], pattern: g[1]})
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown MIME type: %s", typ)
+		}
+	}
+	return res, nil
+}
+
+// Common types, for systems without a shared MIME-info database.
+// Each entry is a MIME type and a pattern.
+var builtinMIMEGlobs = [][2]string{
+	{"application/epub+zip", "*.epub"},
+	{"application/gzip", "*.gz"},
+	{"application/json", "*.json"},
+	{"application/msword", "*.doc"},
+	{"application/pdf", "*.pdf"},
+	{"application/rtf", "*.rtf"},
+	{"application/vnd.ms-excel", "*.xls"},
+	{"application/vnd.ms-powerpoint", "*.ppt"},
+	{"application/vnd.oasis.opendocument.presentation", "*.odp"},
+	{"application/vnd.oasis.opendocument.spreadsheet", "*.ods"},
+	{"application/vnd.oasis.opendocument.text", "*.odt"},
+	{"application/vnd.openxmlformats-officedocument.presentationml.presentation", "*.pptx"},
+	{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "*.xlsx"},
+	{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "*.docx"},
+	{"application/vnd.rar", "*.rar"},
+	{"application/x-7z-compressed", "*.7z"},
+	{"application/x-bzip2", "*.bz2"},
+	{"application/x-compressed-tar", "*.tar.gz"},
+	{"application/x-compressed-tar", "*.tgz"},
+	{"application/x-tar", "*.tar"},
+	{"application/x-xz", "*.xz"},
+	{"application/xml", "*.xml"},
+	{"application/zip", "*.zip"},
+	{"application/zstd", "*.zst"},
+	{"audio/aac", "*.aac"},
+	{"audio/flac", "*.flac"},
+	{"audio/mp4", "*.m4a"},
+	{"audio/mpeg", "*.mp3"},
+	{"audio/ogg", "*.ogg"},
+	{"audio/ogg", "*.oga"},
+	{"audio/opus", "*.opus"},
+	{"audio/x-wav", "*.wav"},
+	{"image/avif", "*.avif"},
+	{"image/bmp", "*.bmp"},
+	{"image/gif", "*.gif"},
+	{"image/heic", "*.heic"},
+	{"image/jpeg", "*.jpg"},
+	{"image/jpeg", "*.jpeg"},
+	{"image/png", "*.png"},
+	{"image/svg+xml", "*.svg"},
+	{"image/tiff", "*.tif"},
+	{"image/tiff", "*.tiff"},
+	{"image/vnd.microsoft.icon", "*.ico"},
+	{"image/webp", "*.webp"},
+	{"text/css", "*.css"},
+	{"text/csv", "*.csv"},
+	{"text/html", "*.html"},
+	{"text/html", "*.htm"},
+	{"text/javascript", "*.js"},
+	{"text/markdown", "*.md"},
+	{"text/plain", "*.txt"},
+	{"video/mp4", "*.mp4"},
+	{"video/mpeg", "*.mpeg"},
+	{"video/mpeg", "*.mpg"},
+	{"video/quicktime", "*.mov"},
+	{"video/webm", "*.webm"},
+	{"video/x-matroska", "*.mkv"},
+	{"video/x-msvideo", "*.avi"},
+}
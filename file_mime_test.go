@@ -0,0 +1,110 @@
+package zenity
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testGlobs2 = `# This file was automatically generated
+50:image/png:*.png
+50:image/jpeg:*.jpg
+50:image/jpeg:*.jpeg
+50:application/pdf:*.pdf
+50:text/x-readme:README*:cs
+50:application/vnd.ms-excel.addin.macroEnabled.12:*.xlam
+malformed line
+`
+
+func Test_parseMIMEGlobs(t *testing.T) {
+	t.Parallel()
+	got := parseMIMEGlobs(strings.NewReader(testGlobs2))
+	want := []mimeGlob{
+		{"image/png", "*.png", false},
+		{"image/jpeg", "*.jpg", false},
+		{"image/jpeg", "*.jpeg", false},
+		{"application/pdf", "*.pdf", false},
+		{"text/x-readme", "README*", true},
+		{"application/vnd.ms-excel.addin.macroEnabled.12", "*.xlam", false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMIMEGlobs() = %v; want %v", got, want)
+	}
+}
+
+func Test_mimePatterns(t *testing.T) {
+	t.Parallel()
+	globs := parseMIMEGlobs(strings.NewReader(testGlobs2))
+	tests := []struct {
+		types []string
+		want  []string
+	}{
+		{[]string{"image/png"}, []string{"*.png"}},
+		{[]string{"image/*"}, []string{"*.png", "*.jpg", "*.jpeg"}},
+		{[]string{"Image/PNG", "image/png"}, []string{"*.png"}},
+		{[]string{"image/*", "application/pdf"}, []string{"*.png", "*.jpg", "*.jpeg", "*.pdf"}},
+		{[]string{"video/webm"}, []string{"*.webm"}},
+		{[]string{"application/vnd.ms-excel.addin.macroenabled.12"}, []string{"*.xlam"}},
+		{[]string{"application/x-unknown"}, nil},
+		{[]string{"image/png", "application/x-unknown"}, nil},
+	}
+	for _, tt := range tests {
+		res, err := mimePatterns(globs, tt.types)
+		var got []string
+		for _, g := range res {
+			got = append(got, g.pattern)
+		}
+		if !reflect.DeepEqual(got, tt.want) || (err == nil) != (tt.want != nil) {
+			t.Errorf("mimePatterns(%q) = %q, %v; want %q", tt.types, got, err, tt.want)
+		}
+	}
+}
+
+func Test_mimePatterns_builtin(t *testing.T) {
+	t.Parallel()
+	got, err := mimePatterns(nil, []string{"image/jpeg", "application/zip"})
+	want := []mimeGlob{
+		{"image/jpeg", "*.jpg", false},
+		{"image/jpeg", "*.jpeg", false},
+		{"application/zip", "*.zip", false},
+	}
+	if !reflect.DeepEqual(got, want) || err != nil {
+		t.Errorf("mimePatterns() = %v, %v; want %v", got, err, want)
+	}
+}
+
+func Test_mimeFilter(t *testing.T) {
+	t.Parallel()
+	globs := parseMIMEGlobs(strings.NewReader(testGlobs2))
+	res, err := mimePatterns(globs, []string{"text/x-readme", "application/pdf"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := mimeFilter("Docs", res)
+	if filter.CaseFold {
+		t.Error("mimeFilter() folded case-sensitive patterns")
+	}
+	for name, want := range map[string]bool{
+		"README.md": true, "readme.md": false,
+		"a.pdf": true, "a.PDF": true, "a.txt": false,
+	} {
+		if got := filter.Match(name); got != want {
+			t.Errorf("mimeFilter().Match(%q) = %v; want %v", name, got, want)
+		}
+	}
+}
+
+func TestFileFilterMIME(t *testing.T) {
+	t.Parallel()
+	filter, err := FileFilterMIME("Documents", "application/pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.Name != "Documents" || !filter.CaseFold {
+		t.Errorf("FileFilterMIME() = %v", filter)
+	}
+	if !filter.Match("report.PDF") {
+		t.Errorf("FileFilterMIME().Match(%q) = false", "report.PDF")
+	}
+}
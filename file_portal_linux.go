@@ -0,0 +1,195 @@
+package zenity
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	portalName = "org.freedesktop.portal.Documents"
+	portalPath = "/org/freedesktop/portal/documents"
+)
+
+// Document portal AddFull flags.
+const (
+	portalReuseExisting   = 1
+	portalExportDirectory = 8
+)
+
+// These are replaced by tests.
+var (
+	portalBus       = func() (*dbus.Conn, error) { return dbus.ConnectSessionBus() }
+	portalSandboxed = func() bool {
+		_, err := os.Stat("/.flatpak-info")
+		return err == nil
+	}
+)
+
+// Export a host path to the sandbox, or else return it unchanged.
+func exportDocument(opts options, path string) string {
+	if !opts.documentPortal || path == "" || !portalSandboxed() {
+		return path
+	}
+	portal, err := openDocumentPortal()
+	if err != nil {
+		return path
+	}
+	defer portal.close()
+	if res, err := portal.export(path); err == nil {
+		return res
+	}
+	return path
+}
+
+// Resolve document paths to host paths, in place, where permitted.
+func importDocuments(opts options, paths []string) {
+	if !opts.documentPortal || len(paths) == 0 {
+		return
+	}
+	portal, err := openDocumentPortal()
+	if err != nil {
+		return
+	}
+	defer portal.close()
+	portal.hostPaths(paths)
+}
+
+type documentPortal struct {
+	conn  *dbus.Conn
+	obj   dbus.BusObject
+	mount string
+}
+
+func openDocumentPortal() (*documentPortal, error) {
+	conn, err := portalBus()
+	if err != nil {
+		return nil, err
+	}
+	obj := conn.Object(portalName, portalPath)
+
+	var mount []byte
+	err = obj.Call(portalName+".GetMountPoint", 0).Store(&mount)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &documentPortal{
+		conn:  conn,
+		obj:   obj,
+		mount: string(bytes.TrimRight(mount, "\x00")),
+	}, nil
+}
+
+func (p *documentPortal) close() error {
+	return p.conn.Close()
+}
+
+// Split a document path, like mount/id/name/rest, into id and /rest.
+func (p *documentPortal) cut(path string) (id, rest string, ok bool) {
+	rest, ok = cutPath(path, p.mount)
+	if !ok || rest == "" {
+		return "", "", false
+	}
+	id, rest, _ = strings.Cut(rest[1:], "/")
+	_, rest, ok = strings.Cut(rest, "/")
+	if ok {
+		rest = "/" + rest
+	}
+	return id, rest, id != ""
+}
+
+func (p *documentPortal) hostPaths(paths []string) error {
+	var ids []string
+	for _, path := range paths {
+		if id, _, ok := p.cut(path); ok {
+			ids = append(ids, id)
+		}
+	}
+	if ids == nil {
+		return nil
+	}
+
+	var hosts map[string][]byte
+	err := p.obj.Call(portalName+".GetHostPaths", 0, ids).Store(&hosts)
+	if err != nil {
+		return err
+	}
+	for i, path := range paths {
+		if id, rest, ok := p.cut(path); ok {
+			if host, ok := hosts[id]; ok && len(host) > 1 {
+				paths[i] = string(bytes.TrimRight(host, "\x00")) + rest
+			}
+		}
+	}
+	return nil
+}
+
+func (p *documentPortal) export(path string) (string, error) {
+	if _, _, ok := p.cut(path); ok {
+		return path, nil
+	}
+
+	dir := strings.HasSuffix(path, "/")
+	path = filepath.Clean(path)
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if errors.Is(err, unix.ENOENT) && !dir {
+		// Export a file that doesn't exist yet, by name.
+		parent, name := filepath.Split(path)
+		fd, err = unix.Open(parent, unix.O_PATH|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return "", err
+		}
+		defer unix.Close(fd)
+
+		var id string
+		err = p.obj.Call(portalName+".AddNamed", 0,
+			dbus.UnixFD(fd), append([]byte(name), 0), true, false).
+			Store(&id)
+		if err != nil {
+			return "", err
+		}
+		return p.document(id, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	defer unix.Close(fd)
+
+	if dir {
+		var ids []string
+		var extra map[string]dbus.Variant
+		err = p.obj.Call(portalName+".AddFull", 0,
+			[]dbus.UnixFD{dbus.UnixFD(fd)}, uint32(portalReuseExisting|portalExportDirectory), "", []string{}).
+			Store(&ids, &extra)
+		if err != nil {
+			return "", err
+		}
+		if len(ids) != 1 {
+			return "", errors.New("document portal: no document")
+		}
+		res, err := p.document(ids[0], filepath.Base(path))
+		return res + "/", err
+	}
+
+	var id string
+	err = p.obj.Call(portalName+".Add", 0,
+		dbus.UnixFD(fd), true, false).
+		Store(&id)
+	if err != nil {
+		return "", err
+	}
+	return p.document(id, filepath.Base(path))
+}
+
+func (p *documentPortal) document(id, name string) (string, error) {
+	if id == "" || strings.Contains(id, "/") {
+		return "", errors.New("document portal: invalid document")
+	}
+	return filepath.Join(p.mount, id, name), nil
+}
@@ -0,0 +1,154 @@
+package zenity
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// fakePortal implements the document portal methods used by zenity.
+type fakePortal struct {
+	mtx   sync.Mutex
+	mount string
+	hosts map[string]string
+}
+
+func (p *fakePortal) serve(path dbus.ObjectPath, method string, args []any) ([]any, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if path != portalPath {
+		return nil, dbus.ErrMsgNoObject
+	}
+	switch method {
+	case portalName + ".GetMountPoint":
+		return []any{append([]byte(p.mount), 0)}, nil
+
+	case portalName + ".GetHostPaths":
+		res := map[string][]byte{}
+		for _, id := range args[0].([]string) {
+			if host, ok := p.hosts[id]; ok {
+				res[id] = append([]byte(host), 0)
+			}
+		}
+		return []any{res}, nil
+
+	case portalName + ".Add":
+		fd := args[0].(dbus.UnixFD)
+		return []any{p.add(fd, "")}, nil
+
+	case portalName + ".AddNamed":
+		fd := args[0].(dbus.UnixFD)
+		name := string(bytes.TrimRight(args[1].([]byte), "\x00"))
+		return []any{p.add(fd, name)}, nil
+	}
+	return nil, dbus.ErrMsgUnknownMethod
+}
+
+func (p *fakePortal) add(fd dbus.UnixFD, name string) string {
+	host, _ := os.Readlink("/proc/self/fd/" + strconv.Itoa(int(fd)))
+	os.NewFile(uintptr(fd), "").Close()
+	id := "doc" + strconv.Itoa(len(p.hosts))
+	p.hosts[id] = filepath.Join(host, name)
+	return id
+}
+
+func (p *fakePortal) host(id string) string {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.hosts[id]
+}
+
+func startFakePortal(t *testing.T) *fakePortal {
+	portal := &fakePortal{
+		mount: filepath.Join(t.TempDir(), "doc"),
+		hosts: map[string]string{"granted": "/home/user/report.pdf"},
+	}
+
+	addr := startTestBus(t)
+	conn := connectTestBus(t, addr, dbus.WithHandler(testHandler(portal.serve)))
+	reply, err := conn.RequestName(portalName, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatal("could not own name:", err)
+	}
+
+	bus, sandboxed := portalBus, portalSandboxed
+	portalBus = func() (*dbus.Conn, error) { return dbus.Connect(addr) }
+	portalSandboxed = func() bool { return true }
+	t.Cleanup(func() { portalBus, portalSandboxed = bus, sandboxed })
+	return portal
+}
+
+func Test_importDocuments(t *testing.T) {
+	portal := startFakePortal(t)
+	opts := options{documentPortal: true}
+
+	paths := []string{
+		portal.mount + "/granted/report.pdf",
+		portal.mount + "/denied/secret.txt",
+		"/home/user/local.txt",
+	}
+	importDocuments(opts, paths)
+
+	want := []string{
+		"/home/user/report.pdf",
+		portal.mount + "/denied/secret.txt",
+		"/home/user/local.txt",
+	}
+	for i := range paths {
+		if paths[i] != want[i] {
+			t.Errorf("importDocuments()[%d] = %q; want %q", i, paths[i], want[i])
+		}
+	}
+}
+
+func Test_exportDocument(t *testing.T) {
+	portal := startFakePortal(t)
+	opts := options{documentPortal: true}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+		host string
+	}{
+		{file, portal.mount + "/doc1/file.txt", file},
+		{dir + "/new.txt", portal.mount + "/doc2/new.txt", dir + "/new.txt"},
+		{portal.mount + "/doc1/file.txt", portal.mount + "/doc1/file.txt", file},
+	}
+	for i, tt := range tests {
+		got := exportDocument(opts, tt.path)
+		if got != tt.want {
+			t.Errorf("exportDocument(%q) = %q; want %q", tt.path, got, tt.want)
+		}
+		if host := portal.host("doc" + strconv.Itoa(i+1)); i < 2 && host != tt.host {
+			t.Errorf("exportDocument(%q) exported %q; want %q", tt.path, host, tt.host)
+		}
+	}
+
+	// Round trip.
+	paths := []string{tests[0].want, tests[1].want}
+	importDocuments(opts, paths)
+	for i, want := range []string{file, dir + "/new.txt"} {
+		if paths[i] != want {
+			t.Errorf("importDocuments()[%d] = %q; want %q", i, paths[i], want)
+		}
+	}
+}
+
+func Test_exportDocument_disabled(t *testing.T) {
+	t.Parallel()
+	if got := exportDocument(options{}, "/home/user"); got != "/home/user" {
+		t.Errorf("exportDocument() = %q", got)
+	}
+}
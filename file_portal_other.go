@@ -0,0 +1,7 @@
+//go:build !linux
+
+package zenity
+
+func exportDocument(opts options, path string) string { return path }
+
+func importDocuments(opts options, paths []string) {}
@@ -0,0 +1,328 @@
+package zenity
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recent returns an Option to offer recently used files.
+//
+// Recently used files are read from the freedesktop.org recently-used.xbel file.
+// On Windows and macOS, which have no such file,
+// one in the state directory of History is used, kept by AddRecentFile.
+// A list of those that match the file filters is shown
+// before the file selection dialog,
+// which is shown only if the user chooses to browse for other files.
+func Recent() Option {
+	return funcOption(func(o *options) { o.recent = true })
+}
+
+// AddRecentFile registers path as recently used,
+// in the recently-used.xbel file read by Recent.
+// On Unix, it then appears in the desktop's list of recent files.
+func AddRecentFile(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	xbel, err := recentFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(xbel), 0700); err != nil {
+		return err
+	}
+
+	// Other applications update the file without locking it,
+	// so read it again right before replacing it, and retry if it changed.
+	for range 3 {
+		old, err := readRecent(xbel)
+		if err != nil {
+			return err
+		}
+		data, err := addRecent(old, path, appName(), time.Now())
+		if err != nil {
+			return err
+		}
+		if cur, err := readRecent(xbel); err != nil || !bytes.Equal(cur, old) {
+			continue
+		}
+		return writeFileAtomic(xbel, bytes.NewReader(data), 0600)
+	}
+	return errors.New("recently used file keeps changing")
+}
+
+// Read the recently used file, which may not exist.
+func readRecent(xbel string) ([]byte, error) {
+	data, err := os.ReadFile(xbel)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Maximum number of recent files to offer.
+const maxRecent = 20
+
+// Show the list of recent files, if requested.
+// Returns an empty path to browse for other files.
+func selectRecent(opts options) (string, error) {
+	if !opts.recent {
+		return "", nil
+	}
+	xbel, err := recentFile()
+	if err != nil {
+		return "", nil
+	}
+	data, err := os.ReadFile(xbel)
+	if err != nil {
+		return "", nil
+	}
+	paths := recentPaths(data, opts)
+	if len(paths) == 0 {
+		return "", nil
+	}
+
+	lst := generalOptions(opts)
+	lst.okLabel = ptr("Open")
+	lst.extraButton = ptr("Browse…")
+	path, err := list("Recently used:", paths, lst)
+	if err == ErrExtraButton {
+		return "", nil
+	}
+	return path, err
+}
+
+func recentFile() (string, error) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		dir, err := stateDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "recently-used.xbel"), nil
+	}
+	dir := os.Getenv("XDG_DATA_HOME")
+	if !filepath.IsAbs(dir) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local/share")
+	}
+	return filepath.Join(dir, "recently-used.xbel"), nil
+}
+
+func appName() string {
+	name := filepath.Base(os.Args[0])
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+type xbelBookmark struct {
+	Href     string `xml:"href,attr"`
+	Modified string `xml:"modified,attr"`
+	Visited  string `xml:"visited,attr"`
+}
+
+// Parse recently used files, and return the most recent that exist,
+// and match the options.
+func recentPaths(data []byte, opts options) []string {
+	var xbel struct {
+		Bookmarks []xbelBookmark `xml:"bookmark"`
+	}
+	if xml.Unmarshal(data, &xbel) != nil {
+		return nil
+	}
+
+	// Most recent first.
+	used := func(b xbelBookmark) string { return max(b.Modified, b.Visited) }
+	slices.SortStableFunc(xbel.Bookmarks, func(a, b xbelBookmark) int {
+		return strings.Compare(used(b), used(a))
+	})
+
+	var res []string
+	for _, b := range xbel.Bookmarks {
+		path := hrefPath(b.Href)
+		if path == "" {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil || fi.IsDir() != opts.directory {
+			continue
+		}
+		if !opts.directory && !opts.fileFilters.Match(path) {
+			continue
+		}
+		if slices.Contains(res, path) {
+			continue
+		}
+		if res = append(res, path); len(res) == maxRecent {
+			break
+		}
+	}
+	return res
+}
+
+// Returns the local path of a file URI, or "".
+func hrefPath(href string) string {
+	u, err := url.Parse(href)
+	if err != nil || u.Scheme != "file" || u.Path == "" {
+		return ""
+	}
+	path := u.Path
+	// On Windows, file:///C:/dir has the path /C:/dir.
+	if filepath.VolumeName(path[1:]) != "" {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// Returns the file URI of an absolute path.
+func pathHref(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+var (
+	xbelBookmarkTag = regexp.MustCompile(`<bookmark\s[^>]*>`)
+	xbelAppTag      = regexp.MustCompile(`<bookmark:application\s[^>]*>`)
+	xbelCountAttr   = regexp.MustCompile(`\scount="(\d+)"`)
+	xmlAttr         = regexp.MustCompile(`\s([\w:.-]+)="[^"]*"`)
+)
+
+// Add path to an XBEL document, or update it if it's already there.
+// The rest of the document is preserved.
+func addRecent(data []byte, path, app string, now time.Time) ([]byte, error) {
+	href := pathHref(path)
+	stamp := now.UTC().Format("2006-01-02T15:04:05.000000Z")
+
+	// Update an existing bookmark.
+	// Other applications escape URIs differently, so compare paths.
+	for _, loc := range xbelBookmarkTag.FindAllIndex(data, -1) {
+		tag := string(data[loc[0]:loc[1]])
+		var b xbelBookmark
+		if xml.Unmarshal([]byte(tag+"</bookmark>"), &b) != nil || hrefPath(b.Href) != path {
+			continue
+		}
+		end := bytes.Index(data[loc[1]:], []byte("</bookmark>"))
+		if end < 0 {
+			return nil, errors.New("invalid recently used file")
+		}
+		end += loc[1]
+
+		tag = setXMLAttr(tag, "modified", stamp)
+		tag = setXMLAttr(tag, "visited", stamp)
+		body := updateRecentApp(string(data[loc[1]:end]), app, stamp)
+
+		var buf bytes.Buffer
+		buf.Write(data[:loc[0]])
+		buf.WriteString(tag)
+		buf.WriteString(body)
+		buf.Write(data[end:])
+		return buf.Bytes(), nil
+	}
+
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	} else {
+		mimeType, _, _ = strings.Cut(mimeType, ";")
+	}
+
+	bookmark := fmt.Sprintf(`  <bookmark href="%s" added="%s" modified="%[2]s" visited="%[2]s">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="%s"/>
+        <bookmark:applications>
+          %s
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+`, quoteMarkup(href), stamp, quoteMarkup(mimeType), recentApp(app, stamp))
+
+	// Add a bookmark to an existing document.
+	if i := bytes.LastIndex(data, []byte("</xbel>")); i >= 0 {
+		var buf bytes.Buffer
+		buf.Write(data[:i])
+		buf.WriteString(bookmark)
+		buf.Write(data[i:])
+		return buf.Bytes(), nil
+	}
+	if len(bytes.TrimSpace(data)) != 0 {
+		return nil, errors.New("invalid recently used file")
+	}
+
+	// Create a new document.
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<xbel version="1.0"
+      xmlns:bookmark="http://www.freedesktop.org/standards/desktop-bookmarks"
+      xmlns:mime="http://www.freedesktop.org/standards/shared-mime-info"
+>
+`)
+	buf.WriteString(bookmark)
+	buf.WriteString("</xbel>\n")
+	return buf.Bytes(), nil
+}
+
+// Update, or add, the application that used a bookmark.
+func updateRecentApp(body, app, stamp string) string {
+	name := `name="` + quoteMarkup(app) + `"`
+	var found bool
+	body = xbelAppTag.ReplaceAllStringFunc(body, func(tag string) string {
+		if found || !strings.Contains(tag, name) {
+			return tag
+		}
+		found = true
+		count := 0
+		if m := xbelCountAttr.FindStringSubmatch(tag); m != nil {
+			count, _ = strconv.Atoi(m[1])
+		}
+		tag = setXMLAttr(tag, "modified", stamp)
+		return setXMLAttr(tag, "count", strconv.Itoa(count+1))
+	})
+	if found {
+		return body
+	}
+
+	elem := recentApp(app, stamp)
+	if i := strings.LastIndex(body, "</bookmark:applications>"); i >= 0 {
+		return body[:i] + "  " + elem + "\n        " + body[i:]
+	}
+	return body
+}
+
+func recentApp(app, stamp string) string {
+	return fmt.Sprintf(`<bookmark:application name="%s" exec="&apos;%[1]s %%u&apos;" modified="%s" count="1"/>`,
+		quoteMarkup(app), stamp)
+}
+
+// Set the value of an attribute in an XML start tag, adding it if needed.
+func setXMLAttr(tag, name, value string) string {
+	attr := " " + name + `="` + quoteMarkup(value) + `"`
+	for _, m := range xmlAttr.FindAllStringSubmatchIndex(tag, -1) {
+		if tag[m[2]:m[3]] == name {
+			return tag[:m[0]] + attr + tag[m[1]:]
+		}
+	}
+	if rest, ok := strings.CutSuffix(tag, "/>"); ok {
+		return rest + attr + "/>"
+	}
+	return strings.TrimSuffix(tag, ">") + attr + ">"
+}
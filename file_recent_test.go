@@ -0,0 +1,178 @@
+package zenity
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testXBEL = `<?xml version="1.0" encoding="UTF-8"?>
+<xbel version="1.0"
+      xmlns:bookmark="http://www.freedesktop.org/standards/desktop-bookmarks"
+      xmlns:mime="http://www.freedesktop.org/standards/shared-mime-info"
+>
+  <bookmark href="https://example.com/" added="2020-01-01T00:00:00Z" modified="2020-01-01T00:00:00Z" visited="2020-01-01T00:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="text/html"/>
+        <bookmark:applications>
+          <bookmark:application name="other" exec="&apos;other %u&apos;" modified="2020-01-01T00:00:00Z" count="3"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+</xbel>
+`
+
+func Test_addRecent(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var paths []string
+	for _, name := range []string{"a.txt", "b.png", "c.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	data := []byte(testXBEL)
+	var err error
+	for i, path := range paths {
+		data, err = addRecent(data, path, "app", now.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Use the first file again.
+	data, err = addRecent(data, paths[0], "app", now.Add(time.Hour*10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Use the second file in another application.
+	data, err = addRecent(data, paths[1], "other", now.Add(time.Hour*5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := xml.Unmarshal(data, new(struct{})); err != nil {
+		t.Fatalf("addRecent() produced invalid XML: %v\n%s", err, data)
+	}
+	if !strings.Contains(string(data), `href="https://example.com/"`) {
+		t.Error("addRecent() lost a bookmark")
+	}
+	if got := strings.Count(string(data), `<bookmark href=`); got != 4 {
+		t.Errorf("addRecent() has %d bookmarks; want 4", got)
+	}
+	if got := strings.Count(string(data), `count="2"`); got != 1 {
+		t.Errorf("addRecent() has %d reused bookmarks; want 1", got)
+	}
+	if got := strings.Count(string(data), `name="other"`); got != 2 {
+		t.Errorf("addRecent() has %d applications named other; want 2", got)
+	}
+
+	got := recentPaths(data, options{})
+	want := []string{paths[0], paths[1], paths[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recentPaths() = %q; want %q", got, want)
+	}
+
+	got = recentPaths(data, options{fileFilters: FileFilters{{"", []string{"*.txt"}, false}}})
+	want = []string{paths[0], paths[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recentPaths(*.txt) = %q; want %q", got, want)
+	}
+
+	if got := recentPaths(data, options{directory: true}); got != nil {
+		t.Errorf("recentPaths(directory) = %q; want nil", got)
+	}
+}
+
+func Test_addRecent_new(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "a b&c.txt")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := addRecent(nil, path, "app", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := recentPaths(data, options{}); !reflect.DeepEqual(got, []string{path}) {
+		t.Errorf("recentPaths() = %q; want %q", got, path)
+	}
+}
+
+func Test_addRecent_escaped(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping: Unix paths")
+	}
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "a b+c~é.txt")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Escape everything but slashes, unlike url.URL.
+	var href strings.Builder
+	href.WriteString("file://")
+	for _, b := range []byte(path) {
+		if b == '/' {
+			href.WriteByte(b)
+		} else {
+			fmt.Fprintf(&href, "%%%02X", b)
+		}
+	}
+	data := []byte(strings.Replace(testXBEL, "https://example.com/", href.String(), 1))
+
+	data, err := addRecent(data, path, "app", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(data), `<bookmark href=`); got != 1 {
+		t.Errorf("addRecent() has %d bookmarks; want 1", got)
+	}
+	if !strings.Contains(string(data), `name="app"`) {
+		t.Error("addRecent() did not update the bookmark")
+	}
+}
+
+func Test_addRecent_invalid(t *testing.T) {
+	t.Parallel()
+	if _, err := addRecent([]byte("garbage"), "/a.txt", "app", time.Now()); err == nil {
+		t.Error("addRecent() did not fail")
+	}
+}
+
+func TestAddRecentFile(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := AddRecentFile(path); err != nil {
+		t.Fatal(err)
+	}
+	xbel, err := recentFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(xbel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := recentPaths(data, options{}); !reflect.DeepEqual(got, []string{path}) {
+		t.Errorf("recentPaths() = %q; want %q", got, path)
+	}
+}
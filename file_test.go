@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -46,6 +47,36 @@ func ExampleSelectFileSave() {
 		})
 }
 
+func ExampleSelectFileSaveFilter() {
+	zenity.SelectFileSaveFilter(
+		zenity.AutoExtension(),
+		zenity.ConfirmOverwrite(),
+		zenity.Filename(defaultPath),
+		zenity.FileFilters{
+			{"PNG images", []string{"*.png"}, true},
+			{"JPEG images", []string{"*.jpg", "*.jpeg"}, true},
+		})
+}
+
+func ExampleValidatePath() {
+	zenity.SelectFile(
+		zenity.Directory(),
+		zenity.ValidatePath(func(path string) error {
+			if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+				return errors.New("Not a git repository.")
+			}
+			return nil
+		}))
+}
+
+func ExampleSaveFile() {
+	zenity.SaveFile(strings.NewReader("Hello, world!\n"),
+		zenity.ConfirmOverwrite(),
+		zenity.ShowErrors(),
+		zenity.Filename(defaultName),
+		zenity.FileFilter{"Text files", []string{"*.txt"}, true})
+}
+
 func ExampleSelectFile_directory() {
 	zenity.SelectFile(
 		zenity.Filename(defaultPath),
@@ -71,6 +102,13 @@ var fileFuncs = []struct {
 		_, err := zenity.SelectFileMultiple(append(o, zenity.Directory())...)
 		return "", err
 	}},
+	{"OpenFile", func(o ...zenity.Option) (string, error) {
+		_, err := zenity.OpenFile(o...)
+		return "", err
+	}},
+	{"SaveFile", func(o ...zenity.Option) (string, error) {
+		return zenity.SaveFile(strings.NewReader(""), o...)
+	}},
 	{"MultipleDirectory", func(o ...zenity.Option) (string, error) {
 		_, err := zenity.SelectFileMultiple(o...)
 		return "", err
@@ -23,16 +23,17 @@ func selectFileMultiple(opts options) ([]string, error) {
 	args = appendFileArgs(args, opts)
 
 	out, err := zenutil.Run(opts.ctx, args)
-	return lstResult(opts, out, err)
+	return lstResult(opts, validPath, out, err)
 }
 
-func selectFileSave(opts options) (string, error) {
+func selectFileSave(opts options) (string, int, error) {
 	args := []string{"--file-selection", "--save"}
 	args = appendGeneral(args, opts)
 	args = appendFileArgs(args, opts)
 
 	out, err := zenutil.Run(opts.ctx, args)
-	return strResult(opts, out, err)
+	str, err := strResult(opts, out, err)
+	return str, -1, err
 }
 
 func initFilters(filters FileFilters) []string {
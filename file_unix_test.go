@@ -0,0 +1,42 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_validatePaths_rejected(t *testing.T) {
+	dir := installFakeTool(t, `#!/bin/sh
+printf '%s\n' "$*" >> "$(dirname "$0")/args"
+`)
+
+	opts := applyOptions([]Option{ValidatePath(func(path string) error {
+		if strings.HasSuffix(path, ".exe") {
+			return errors.New("executables are not allowed")
+		}
+		return nil
+	})})
+
+	ok, err := validatePaths(&opts, "a.txt", "b.exe", "c.exe")
+	if ok || err != nil {
+		t.Errorf("validatePaths() = %v, %v; want false, nil", ok, err)
+	}
+	if opts.filename != "b.exe" {
+		t.Errorf("validatePaths() filename = %q; want %q", opts.filename, "b.exe")
+	}
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	if err != nil {
+		t.Fatal("validatePaths() did not show the error:", err)
+	}
+	if s := string(args); !strings.Contains(s, "--error") ||
+		!strings.Contains(s, "executables are not allowed") ||
+		strings.Count(s, "\n") != 1 {
+		t.Errorf("validatePaths() showed %q", s)
+	}
+}
@@ -38,3 +38,48 @@ func Test_splitDirAndName(t *testing.T) {
 		}
 	}
 }
+
+func Test_normalizeFilename(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("ZENITY_TEST_DIR", home)
+	t.Setenv("ZENITY_TEST_EMPTY", "")
+	t.Setenv("ZENITY_TEST_UNSET", "")
+	os.Unsetenv("ZENITY_TEST_UNSET")
+	if err := os.Mkdir(filepath.Join(home, "docs"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sep := string(filepath.Separator)
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", ""},
+		{"$ZENITY_TEST_EMPTY", ""},
+		{"${}", filepath.Join(cwd, "${}")},
+		{"~", home + sep},
+		{"~" + sep + "docs", filepath.Join(home, "docs") + sep},
+		{"~" + sep + "report.pdf", filepath.Join(home, "report.pdf")},
+		{"~user", filepath.Join(cwd, "~user")},
+		{"$ZENITY_TEST_DIR" + sep + "docs" + sep, filepath.Join(home, "docs") + sep},
+		{"${ZENITY_TEST_DIR}" + sep + "a.txt", filepath.Join(home, "a.txt")},
+		{filepath.Join(home, "$ZENITY_TEST_UNSET"), filepath.Join(home, "$ZENITY_TEST_UNSET")},
+		{filepath.Join(home, "${ZENITY_TEST_UNSET}"), filepath.Join(home, "${ZENITY_TEST_UNSET}")},
+		{filepath.Join(home, "a$"), filepath.Join(home, "a$")},
+		{filepath.Join(home, "new") + sep, filepath.Join(home, "new") + sep},
+		{"report.pdf", filepath.Join(cwd, "report.pdf")},
+		{".", cwd + sep},
+	}
+	for _, tt := range tests {
+		if got := normalizeFilename(tt.path); got != tt.want {
+			t.Errorf("normalizeFilename(%q) = %q; want %q", tt.path, got, tt.want)
+		}
+	}
+}
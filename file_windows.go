@@ -129,13 +129,14 @@ func selectFileMultiple(opts options) ([]string, error) {
 	return split, nil
 }
 
-func selectFileSave(opts options) (string, error) {
+func selectFileSave(opts options) (string, int, error) {
 	if opts.directory {
-		return selectFile(opts)
+		name, err := selectFile(opts)
+		return name, -1, err
 	}
-	name, shown, err := fileSaveDialog(opts)
+	name, index, shown, err := fileSaveDialog(opts)
 	if shown || opts.ctx != nil && opts.ctx.Err() != nil {
-		return name, err
+		return name, filterIndex(opts.fileFilters, index), err
 	}
 
 	var args win.OPENFILENAME
@@ -167,18 +168,18 @@ func selectFileSave(opts options) (string, error) {
 	defer setup(args.Owner)()
 	unhook, err := hookDialog(opts.ctx, opts.windowIcon, nil, nil)
 	if err != nil {
-		return "", err
+		return "", -1, err
 	}
 	defer unhook()
 
 	ok := win.GetSaveFileName(&args)
 	if opts.ctx != nil && opts.ctx.Err() != nil {
-		return "", opts.ctx.Err()
+		return "", -1, opts.ctx.Err()
 	}
 	if !ok {
-		return "", win.CommDlgError()
+		return "", -1, win.CommDlgError()
 	}
-	return syscall.UTF16ToString(res[:]), nil
+	return syscall.UTF16ToString(res[:]), filterIndex(opts.fileFilters, int(args.FilterIndex)), nil
 }
 
 func fileOpenDialog(opts options, multi bool) (string, []string, bool, error) {
@@ -299,10 +300,10 @@ func fileOpenDialog(opts options, multi bool) (string, []string, bool, error) {
 	}
 }
 
-func fileSaveDialog(opts options) (string, bool, error) {
+func fileSaveDialog(opts options) (string, int, bool, error) {
 	uninit, err := coInitialize()
 	if err != nil {
-		return "", false, err
+		return "", 0, false, err
 	}
 	defer uninit()
 
@@ -314,13 +315,13 @@ func fileSaveDialog(opts options) (string, bool, error) {
 		win.CLSID_FileSaveDialog, nil, win.CLSCTX_ALL,
 		win.IID_IFileSaveDialog, unsafe.Pointer(&dialog))
 	if err != nil {
-		return "", false, err
+		return "", 0, false, err
 	}
 	defer dialog.Release()
 
 	flgs, err := dialog.GetOptions()
 	if err != nil {
-		return "", false, err
+		return "", 0, false, err
 	}
 	flgs |= win.FOS_NOCHANGEDIR | win.FOS_PATHMUSTEXIST | win.FOS_NOREADONLYRETURN | win.FOS_FORCEFILESYSTEM
 	if opts.confirmOverwrite {
@@ -334,7 +335,7 @@ func fileSaveDialog(opts options) (string, bool, error) {
 	}
 	err = dialog.SetOptions(flgs)
 	if err != nil {
-		return "", false, err
+		return "", 0, false, err
 	}
 
 	if opts.title != nil {
@@ -360,7 +361,7 @@ func fileSaveDialog(opts options) (string, bool, error) {
 
 	unhook, err := hookDialog(opts.ctx, opts.windowIcon, nil, nil)
 	if err != nil {
-		return "", false, err
+		return "", 0, false, err
 	}
 	defer unhook()
 
@@ -378,20 +379,21 @@ func fileSaveDialog(opts options) (string, bool, error) {
 
 	err = dialog.Show(owner)
 	if opts.ctx != nil && opts.ctx.Err() != nil {
-		return "", true, opts.ctx.Err()
+		return "", 0, true, opts.ctx.Err()
 	}
 	if err == win.E_CANCELED {
-		return "", true, ErrCanceled
+		return "", 0, true, ErrCanceled
 	}
 	if err != nil {
-		return "", true, err
+		return "", 0, true, err
 	}
 
 	str, err := shellItemPath(dialog.GetResult())
 	if err != nil {
-		return "", true, err
+		return "", 0, true, err
 	}
-	return str, true, nil
+	index, _ := dialog.GetFileTypeIndex()
+	return str, int(index), true, nil
 }
 
 func shellItemPath(item *win.IShellItem, err error) (string, error) {
@@ -486,6 +488,20 @@ func initDirNameExt(filename string, name []uint16) (dir *uint16, ext *uint16) {
 	return
 }
 
+// Convert the one-based index of a (simplified) filter shown by the dialog
+// to an index into filters, or -1.
+func filterIndex(filters FileFilters, index int) int {
+	for i, f := range filters {
+		if len(f.Patterns) == 0 {
+			continue
+		}
+		if index--; index == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
 func initFilters(filters FileFilters) *uint16 {
 	filters.simplify()
 	filters.name()
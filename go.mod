@@ -4,6 +4,7 @@ go 1.25.0
 
 require (
 	github.com/dchest/jsmin v1.0.0
+	github.com/godbus/dbus/v5 v5.2.2
 	github.com/josephspurrier/goversioninfo v1.5.0
 	github.com/ncruces/go-strftime v1.0.0
 	github.com/randall77/makefat v0.0.0-20210315173500-7ddd0e42c844
@@ -0,0 +1,82 @@
+package zenity
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// History returns an Option to remember the values entered in
+// Entry dialogs with the same key, and offer them as choices
+// (Unix and Windows only).
+//
+// The most recent values are stored in $XDG_STATE_HOME/zenity.
+// Hidden text is never stored.
+// If storing a value fails, it is returned along with the error.
+//
+// On Unix, choices are passed to zenity as arguments, so other users may
+// see them in the process list. Values that start with "-",
+// or that are longer than 1024 bytes, are not offered.
+func History(key string) Option {
+	return funcOption(func(o *options) { o.history = &key })
+}
+
+// ClearHistory forgets the values remembered for key.
+func ClearHistory(key string) error {
+	path, err := statePath("history", key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Maximum number of values to remember.
+const maxHistory = 20
+
+func loadHistory(opts *options) {
+	if opts.history == nil || opts.hideText {
+		return
+	}
+	data, err := readState("history", *opts.history)
+	if err != nil {
+		return
+	}
+	var values []string
+	if json.Unmarshal(data, &values) == nil {
+		opts.choices = values[:min(len(values), maxHistory)]
+	}
+}
+
+func saveHistory(opts options, value string) error {
+	if opts.history == nil || opts.hideText || value == "" {
+		return nil
+	}
+	values := addHistory(opts.choices, value)
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return writeState("history", *opts.history, data)
+}
+
+// Add value to the front of values, removing duplicates,
+// and keeping at most maxHistory values.
+func addHistory(values []string, value string) []string {
+	res := make([]string, 0, len(values)+1)
+	res = append(res, value)
+	for _, v := range values {
+		if v != value && !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+	if len(res) > maxHistory {
+		res = res[:maxHistory]
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package zenity
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_addHistory(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		values []string
+		value  string
+		want   []string
+	}{
+		{nil, "a", []string{"a"}},
+		{[]string{"a", "b"}, "c", []string{"c", "a", "b"}},
+		{[]string{"a", "b", "c"}, "b", []string{"b", "a", "c"}},
+		{[]string{"a", "b", "a"}, "c", []string{"c", "a", "b"}},
+	}
+	for _, tt := range tests {
+		if got := addHistory(tt.values, tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("addHistory(%q, %q) = %q; want %q", tt.values, tt.value, got, tt.want)
+		}
+	}
+
+	var values []string
+	for i := range 2 * maxHistory {
+		values = addHistory(values, strconv.Itoa(i))
+	}
+	if len(values) != maxHistory || values[0] != strconv.Itoa(2*maxHistory-1) {
+		t.Errorf("addHistory() = %q", values)
+	}
+}
+
+func Test_saveHistory(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	for _, value := range []string{"one", "two", "", "one"} {
+		opts := applyOptions([]Option{History("key")})
+		loadHistory(&opts)
+		if err := saveHistory(opts, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hidden := applyOptions([]Option{History("key"), HideText()})
+	if err := saveHistory(hidden, "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := applyOptions([]Option{History("key")})
+	loadHistory(&opts)
+	if want := []string{"one", "two"}; !reflect.DeepEqual(opts.choices, want) {
+		t.Errorf("loadHistory() = %q; want %q", opts.choices, want)
+	}
+	loadHistory(&hidden)
+	if hidden.choices != nil {
+		t.Errorf("loadHistory(HideText) = %q", hidden.choices)
+	}
+
+	if err := ClearHistory("key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ClearHistory("key"); err != nil {
+		t.Fatal(err)
+	}
+	opts = applyOptions([]Option{History("key")})
+	loadHistory(&opts)
+	if opts.choices != nil {
+		t.Errorf("loadHistory() = %q after ClearHistory", opts.choices)
+	}
+}
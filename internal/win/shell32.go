@@ -206,6 +206,15 @@ func (u *IFileDialog) SetFileTypes(cFileTypes int, rgFilterSpec *COMDLG_FILTERSP
 	return
 }
 
+func (u *IFileDialog) GetFileTypeIndex() (index uint32, err error) {
+	vtbl := *(**iFileDialogVtbl)(unsafe.Pointer(u))
+	hr, _, _ := u.call(vtbl.GetFileTypeIndex, uintptr(unsafe.Pointer(&index)))
+	if hr != 0 {
+		err = syscall.Errno(hr)
+	}
+	return
+}
+
 func (u *IFileDialog) SetOptions(fos _FILEOPENDIALOGOPTIONS) (err error) {
 	vtbl := *(**iFileDialogVtbl)(unsafe.Pointer(u))
 	hr, _, _ := u.call(vtbl.SetOptions, uintptr(fos))
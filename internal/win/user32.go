@@ -4,6 +4,8 @@ package win
 
 import (
 	"syscall"
+	"unicode/utf16"
+	"unicode/utf8"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -52,6 +54,8 @@ const (
 	WM_DPICHANGED  = 0x02e0
 	WM_USER        = 0x0400
 	EM_SETSEL      = 0x00b1
+	CB_SETEDITSEL  = 0x0142
+	CB_ADDSTRING   = 0x0143
 	LB_ADDSTRING   = 0x0180
 	LB_SETSEL      = 0x0185
 	LB_SETCURSEL   = 0x0186
@@ -142,6 +146,10 @@ const (
 	ES_PASSWORD    = 0x0020
 	ES_AUTOHSCROLL = 0x0080
 
+	// Combo box control styles
+	CBS_DROPDOWN    = 0x0002
+	CBS_AUTOHSCROLL = 0x0040
+
 	// List box control styles
 	LBS_NOTIFY      = 0x0001
 	LBS_EXTENDEDSEL = 0x0800
@@ -277,6 +285,32 @@ func GetWindowText(wnd HWND) string {
 	return windows.UTF16ToString(buf)
 }
 
+// GetWindowTextBytes returns the text of a window, as UTF-8.
+// Intermediate buffers are wiped, so the text can be a secret.
+func GetWindowTextBytes(wnd HWND) []byte {
+	size, _ := getWindowTextLength(wnd)
+	if size == 0 {
+		return nil
+	}
+	buf := make([]uint16, size+1)
+	defer clear(buf)
+	n, _ := getWindowText(wnd, &buf[0], size+1)
+	buf = buf[:n]
+
+	res := make([]byte, 0, 3*n)
+	for i := 0; i < len(buf); i++ {
+		r := rune(buf[i])
+		if utf16.IsSurrogate(r) && i+1 < len(buf) {
+			if dec := utf16.DecodeRune(r, rune(buf[i+1])); dec != utf8.RuneError {
+				r = dec
+				i++
+			}
+		}
+		res = utf8.AppendRune(res, r)
+	}
+	return res
+}
+
 func SendMessagePointer(wnd HWND, msg uint32, wparam uintptr, lparam unsafe.Pointer) (ret uintptr) {
 	r0, _, _ := syscall.SyscallN(procSendMessageW.Addr(), uintptr(wnd), uintptr(msg), uintptr(wparam), uintptr(lparam))
 	ret = uintptr(r0)
@@ -5,9 +5,11 @@ import "time"
 
 // These are internal.
 const (
-	ErrCanceled    = stringErr("dialog canceled")
-	ErrExtraButton = stringErr("extra button pressed")
-	ErrUnsupported = stringErr("unsupported option")
+	ErrCanceled       = stringErr("dialog canceled")
+	ErrExtraButton    = stringErr("extra button pressed")
+	ErrUnsupported    = stringErr("unsupported option")
+	ErrFilterMismatch = stringErr("file does not match filters")
+	ErrNoCredentials  = stringErr("no stored credentials")
 )
 
 // These are internal.
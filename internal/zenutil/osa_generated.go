@@ -143,11 +143,12 @@ if(res.buttonReturned==={{json .Extra}}){$.puts({{json .Extra}})
 $.exit(1)}
 return res.textReturned}
 var start=Date.now()
-opts.defaultAnswer=''
+opts.defaultAnswer={{json .Username}}
 var username=dialog('Username:')
 {{- if .Options.Timeout}}
 opts.givingUpAfter-=(Date.now()-start)/1000|0
 {{- end}}
+opts.defaultAnswer=''
 opts.hiddenAnswer=true
 var password=dialog('Password:')
 username+{{json .Separator}}+password
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package zenutil
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// NewProcessGroup is internal.
+func NewProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	cmd.SysProcAttr.Pgid = 0
+}
+
+// KillProcessGroup is internal.
+func KillProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
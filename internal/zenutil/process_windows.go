@@ -0,0 +1,19 @@
+package zenutil
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// NewProcessGroup is internal.
+func NewProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+}
+
+// KillProcessGroup is internal.
+func KillProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
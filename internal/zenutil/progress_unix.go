@@ -60,6 +60,15 @@ func (d *progressDialog) Done() <-chan struct{} {
 	return d.done
 }
 
+func (d *progressDialog) Err() error {
+	select {
+	default:
+		return nil
+	case <-d.done:
+		return d.err
+	}
+}
+
 func (d *progressDialog) Complete() error {
 	err := d.Value(d.max)
 	close(d.lines)
@@ -45,6 +45,28 @@ func Run(ctx context.Context, script string, data any) ([]byte, error) {
 	return cmd.Output()
 }
 
+// RunSecret is internal.
+func RunSecret(ctx context.Context, script string, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := scripts.ExecuteTemplate(&buf, script, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx != nil {
+		cmd := exec.CommandContext(ctx, "osascript", "-l", "JavaScript")
+		cmd.Stdin = &buf
+		out, err := runSecret(cmd)
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return out, err
+	}
+	cmd := exec.Command("osascript", "-l", "JavaScript")
+	cmd.Stdin = &buf
+	return runSecret(cmd)
+}
+
 // RunProgress is internal.
 func RunProgress(ctx context.Context, max int, close bool, data Progress) (_ *progressDialog, err error) {
 	var buf bytes.Buffer
@@ -156,6 +178,7 @@ type DialogOptions struct {
 // Password is internal.
 type Password struct {
 	Separator string
+	Username  string
 	Extra     *string
 	Options   PasswordOptions
 	IconPath  string
@@ -10,11 +10,15 @@ import (
 	"strconv"
 	"sync"
 	"syscall"
+
+	"golang.org/x/sys/unix"
 )
 
 var (
 	tool, path string
 	pathOnce   sync.Once
+	yadPath    string
+	yadOnce    sync.Once
 )
 
 func initPath() {
@@ -33,6 +37,12 @@ func IsAvailable() bool {
 	return path != ""
 }
 
+// Yad is internal.
+func Yad() string {
+	yadOnce.Do(func() { yadPath, _ = exec.LookPath("yad") })
+	return yadPath
+}
+
 // Run is internal.
 func Run(ctx context.Context, args []string) ([]byte, error) {
 	pathOnce.Do(initPath)
@@ -53,6 +63,51 @@ func Run(ctx context.Context, args []string) ([]byte, error) {
 	return exec.Command(tool, args...).Output()
 }
 
+// RunInput is internal.
+func RunInput(ctx context.Context, args []string, input []byte) ([]byte, error) {
+	pathOnce.Do(initPath)
+	if Command && path != "" {
+		if Timeout > 0 {
+			args = append(args, "--timeout", strconv.Itoa(Timeout))
+		}
+		// Try to use syscall.Exec, with input as stdin, fallback to exec.Command.
+		if t, err := os.CreateTemp("", ""); err != nil {
+		} else if err := os.Remove(t.Name()); err != nil {
+		} else if _, err := t.Write(input); err != nil {
+		} else if _, err := t.Seek(0, 0); err != nil {
+		} else if err := unix.Dup2(int(t.Fd()), unix.Stdin); err != nil {
+		} else {
+			syscall.Exec(path, append([]string{tool}, args...), os.Environ())
+		}
+	}
+
+	var cmd *exec.Cmd
+	if ctx != nil {
+		cmd = exec.CommandContext(ctx, tool, args...)
+	} else {
+		cmd = exec.Command(tool, args...)
+	}
+	cmd.Stdin = bytes.NewReader(input)
+	out, err := cmd.Output()
+	if ctx != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return out, err
+}
+
+// RunSecret is internal.
+func RunSecret(ctx context.Context, args []string) ([]byte, error) {
+	pathOnce.Do(initPath)
+	if ctx != nil {
+		out, err := runSecret(exec.CommandContext(ctx, tool, args...))
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return out, err
+	}
+	return runSecret(exec.Command(tool, args...))
+}
+
 // RunProgress is internal.
 func RunProgress(ctx context.Context, max int, close bool, extra *string, args []string) (*progressDialog, error) {
 	pathOnce.Do(initPath)
@@ -51,3 +51,17 @@ func skip(err error) (bool, error) {
 	}
 	return false, err
 }
+
+func Test_runSecret(t *testing.T) {
+	out, err := runSecret(exec.Command("sh", "-c", "echo secret; echo secret >&2; exit 2"))
+	if string(out) != "secret\n" {
+		t.Errorf("runSecret() = %q", out)
+	}
+	eerr, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("runSecret() = %v", err)
+	}
+	if len(eerr.Stderr) != 0 {
+		t.Errorf("runSecret() kept stderr %q", eerr.Stderr)
+	}
+}
@@ -0,0 +1,53 @@
+package zenutil
+
+import (
+	"io"
+	"os/exec"
+	"runtime"
+)
+
+// ReadSecret is internal.
+// It reads all of r, wiping any buffers it discards.
+func ReadSecret(r io.Reader) ([]byte, error) {
+	buf := make([]byte, 0, 512)
+	for {
+		if len(buf) == cap(buf) {
+			grown := make([]byte, len(buf), 2*cap(buf))
+			copy(grown, buf)
+			Wipe(buf)
+			buf = grown
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			return buf, err
+		}
+	}
+}
+
+// Wipe is internal.
+func Wipe(b []byte) {
+	clear(b[:cap(b)])
+	runtime.KeepAlive(b)
+}
+
+// Run cmd, reading its output with ReadSecret.
+// Standard error is discarded, rather than kept in an exec.ExitError.
+func runSecret(cmd *exec.Cmd) ([]byte, error) {
+	pipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	out, rerr := ReadSecret(pipe)
+	err = cmd.Wait()
+	if err == nil {
+		err = rerr
+	}
+	return out, err
+}
@@ -0,0 +1,34 @@
+package zenutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadSecret(t *testing.T) {
+	t.Parallel()
+	want := strings.Repeat("secret", 1000)
+	got, err := ReadSecret(iotest.HalfReader(strings.NewReader(want)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("ReadSecret() = %q; want %q", got, want)
+	}
+
+	_, err = ReadSecret(iotest.ErrReader(iotest.ErrTimeout))
+	if err != iotest.ErrTimeout {
+		t.Errorf("ReadSecret() = %v; want %v", err, iotest.ErrTimeout)
+	}
+}
+
+func TestWipe(t *testing.T) {
+	t.Parallel()
+	buf := []byte("secret")
+	Wipe(buf[:2])
+	if !bytes.Equal(buf, make([]byte, len(buf))) {
+		t.Errorf("Wipe() = %q", buf)
+	}
+}
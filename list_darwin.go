@@ -56,5 +56,5 @@ func listMultiple(text string, items []string, opts options) ([]string, error) {
 	data.Separator = zenutil.Separator
 
 	out, err := zenutil.Run(opts.ctx, "list", data)
-	return lstResult(opts, out, err)
+	return lstResult(opts, validItems(items), out, err)
 }
@@ -43,6 +43,21 @@ func ExampleListMultipleItems() {
 		"apples", "oranges", "bananas", "strawberries")
 }
 
+func ExampleListTree() {
+	zenity.ListTree(
+		"Select a namespace:",
+		[]zenity.TreeItem{
+			{Name: "production", Children: []zenity.TreeItem{
+				{Name: "eu-west", Children: []zenity.TreeItem{{Name: "default"}, {Name: "monitoring"}}},
+				{Name: "us-east", Children: []zenity.TreeItem{{Name: "default"}}},
+			}},
+			{Name: "staging", Children: []zenity.TreeItem{
+				{Name: "local", Children: []zenity.TreeItem{{Name: "default"}}},
+			}},
+		},
+		zenity.Title("Select a namespace"))
+}
+
 func TestList_timeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
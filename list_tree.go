@@ -0,0 +1,64 @@
+package zenity
+
+// TreeItem is an item of the tree list dialog.
+// Items with children are groups, only items without children can be selected.
+type TreeItem struct {
+	Name     string
+	Children []TreeItem
+}
+
+// ListTree displays the list dialog, with a tree of items.
+// It returns the path to the selected item:
+// the names of its groups, followed by its own name.
+//
+// On Unix, each level of the tree is shown in its own column,
+// and group names are only shown in the first row of their group.
+// On Windows and macOS, each item is shown with its path.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, MidSearch, DisallowEmpty.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
+func ListTree(text string, items []TreeItem, options ...Option) ([]string, error) {
+	paths := treePaths(nil, items)
+	sel, err := listTree(text, paths, false, applyOptions(options))
+	if err != nil || len(sel) == 0 {
+		return nil, err
+	}
+	return paths[sel[0]], nil
+}
+
+// ListTreeMultiple displays the list dialog, with a tree of items,
+// allowing multiple items to be selected.
+// It returns the paths to the selected items.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, MidSearch, DisallowEmpty.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
+func ListTreeMultiple(text string, items []TreeItem, options ...Option) ([][]string, error) {
+	paths := treePaths(nil, items)
+	sel, err := listTree(text, paths, true, applyOptions(options))
+	if err != nil {
+		return nil, err
+	}
+	res := make([][]string, len(sel))
+	for i, s := range sel {
+		res[i] = paths[s]
+	}
+	return res, nil
+}
+
+// Returns the paths to the selectable items of a tree, in order.
+func treePaths(parent []string, items []TreeItem) [][]string {
+	var res [][]string
+	for _, item := range items {
+		path := append(parent[:len(parent):len(parent)], item.Name)
+		if len(item.Children) == 0 {
+			res = append(res, path)
+		} else {
+			res = append(res, treePaths(path, item.Children)...)
+		}
+	}
+	return res
+}
@@ -0,0 +1,38 @@
+//go:build windows || darwin
+
+package zenity
+
+import "strings"
+
+// Show each item with its path, and find the selected items by path.
+func listTree(text string, paths [][]string, multiple bool, opts options) ([]int, error) {
+	labels := make([]string, len(paths))
+	index := make(map[string]int, len(paths))
+	for i, p := range paths {
+		labels[i] = strings.Join(p, " › ")
+		if _, ok := index[labels[i]]; !ok {
+			index[labels[i]] = i
+		}
+	}
+
+	var sel []string
+	var err error
+	if multiple {
+		sel, err = listMultiple(text, labels, opts)
+	} else {
+		var s string
+		s, err = list(text, labels, opts)
+		if s != "" {
+			sel = []string{s}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]int, len(sel))
+	for i, s := range sel {
+		res[i] = index[s]
+	}
+	return res, nil
+}
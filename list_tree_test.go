@@ -0,0 +1,28 @@
+package zenity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_treePaths(t *testing.T) {
+	t.Parallel()
+	tree := []TreeItem{
+		{Name: "a", Children: []TreeItem{
+			{Name: "b", Children: []TreeItem{{Name: "c"}, {Name: "d"}}},
+			{Name: "e"},
+		}},
+		{Name: "f"},
+		{Name: "g", Children: []TreeItem{{Name: "h"}}},
+	}
+	want := [][]string{
+		{"a", "b", "c"},
+		{"a", "b", "d"},
+		{"a", "e"},
+		{"f"},
+		{"g", "h"},
+	}
+	if got := treePaths(nil, tree); !reflect.DeepEqual(got, want) {
+		t.Errorf("treePaths() = %q; want %q", got, want)
+	}
+}
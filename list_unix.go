@@ -3,32 +3,53 @@
 package zenity
 
 import (
+	"bytes"
+	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/ncruces/zenity/internal/zenutil"
 )
 
+// Lists have a hidden column with the index of each item,
+// which is printed instead of the item,
+// so items can contain anything, including the separator.
 func list(text string, items []string, opts options) (string, error) {
 	args := []string{"--list", "--hide-header", "--text", text}
 	args = appendGeneral(args, opts)
 	args = appendButtons(args, opts)
 	args = appendWidthHeight(args, opts)
 	args = appendWindowIcon(args, opts)
+
+	var rows bytes.Buffer
 	if opts.listKind == radioListKind {
-		args = append(args, "--radiolist", "--column=", "--column=")
-		for _, i := range items {
-			args = append(args, "", i)
+		args = append(args, "--radiolist", "--column=", "--column=", "--column=",
+			"--hide-column=2", "--print-column=2")
+		for i, item := range items {
+			appendCells(&rows, "FALSE", strconv.Itoa(i), item)
 		}
 	} else {
-		args = append(args, "--column=")
-		args = append(args, items...)
+		args = append(args, "--column=", "--column=",
+			"--hide-column=1", "--print-column=1")
+		for i, item := range items {
+			appendCells(&rows, strconv.Itoa(i), item)
+		}
 	}
 	if opts.midSearch {
 		args = append(args, "--mid-search")
 	}
 
-	out, err := zenutil.Run(opts.ctx, args)
-	return strResult(opts, out, err)
+	out, err := zenutil.RunInput(opts.ctx, args, rows.Bytes())
+	str, err := strResult(opts, out, err)
+	if err != nil || str == "" {
+		return "", err
+	}
+	res, err := listItems(items, str)
+	if err != nil {
+		return "", err
+	}
+	return res[0], nil
 }
 
 func listMultiple(text string, items []string, opts options) ([]string, error) {
@@ -40,20 +61,119 @@ func listMultiple(text string, items []string, opts options) ([]string, error) {
 
 	// Having multiple items selected by default is only supported for checklists.
 	// In case user provides non-empty list of default items, checklist will be enforced to avoid confusion.
+	var rows bytes.Buffer
 	if opts.listKind == checkListKind || len(opts.defaultItems) > 0 {
-		args = append(args, "--checklist", "--column=", "--column=")
-		for _, i := range items {
-			selected := ""
-			if slices.Contains(opts.defaultItems, i) {
-				selected = "true"
+		args = append(args, "--checklist", "--column=", "--column=", "--column=",
+			"--hide-column=2", "--print-column=2")
+		for i, item := range items {
+			selected := "FALSE"
+			if slices.Contains(opts.defaultItems, item) {
+				selected = "TRUE"
 			}
-			args = append(args, selected, i)
+			appendCells(&rows, selected, strconv.Itoa(i), item)
 		}
 	} else {
+		args = append(args, "--column=", "--column=",
+			"--hide-column=1", "--print-column=1")
+		for i, item := range items {
+			appendCells(&rows, strconv.Itoa(i), item)
+		}
+	}
+
+	out, err := zenutil.RunInput(opts.ctx, args, rows.Bytes())
+	str, err := strResult(opts, out, err)
+	if err != nil {
+		return nil, err
+	}
+	if str == "" {
+		return []string{}, nil
+	}
+	return listItems(items, str)
+}
+
+// Rows are read from stdin, one cell per line,
+// so line breaks in cells are replaced with spaces.
+func appendCells(buf *bytes.Buffer, cells ...string) {
+	for _, c := range cells {
+		if strings.ContainsAny(c, "\r\n") {
+			c = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(c)
+		}
+		buf.WriteString(c)
+		buf.WriteByte('\n')
+	}
+}
+
+// Shows each level of the tree in a column,
+// and group names only in the first row of their group.
+func listTree(text string, paths [][]string, multiple bool, opts options) ([]int, error) {
+	args := []string{"--list", "--hide-header", "--text", text}
+	if multiple {
+		args = append(args, "--multiple", "--separator", zenutil.Separator)
+	}
+	args = appendGeneral(args, opts)
+	args = appendButtons(args, opts)
+	args = appendWidthHeight(args, opts)
+	args = appendWindowIcon(args, opts)
+	if opts.midSearch {
+		args = append(args, "--mid-search")
+	}
+
+	var depth int
+	for _, p := range paths {
+		depth = max(depth, len(p))
+	}
+	args = append(args, "--column=", "--hide-column=1", "--print-column=1")
+	for range depth {
 		args = append(args, "--column=")
-		args = append(args, items...)
 	}
 
-	out, err := zenutil.Run(opts.ctx, args)
-	return lstResult(opts, out, err)
+	var rows bytes.Buffer
+	for i, p := range paths {
+		appendCells(&rows, strconv.Itoa(i))
+		for d := range depth {
+			var cell string
+			switch {
+			case d >= len(p):
+			case d < len(p)-1 && i > 0 && len(paths[i-1]) > d+1 &&
+				slices.Equal(p[:d+1], paths[i-1][:d+1]):
+				// Same group as the previous row.
+			default:
+				cell = p[d]
+			}
+			appendCells(&rows, cell)
+		}
+	}
+
+	out, err := zenutil.RunInput(opts.ctx, args, rows.Bytes())
+	str, err := strResult(opts, out, err)
+	if err != nil || str == "" {
+		return nil, err
+	}
+	return listIndexes(len(paths), str)
+}
+
+// Returns the items selected by a list of indexes.
+func listItems(items []string, indexes string) ([]string, error) {
+	idx, err := listIndexes(len(items), indexes)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, len(idx))
+	for i, j := range idx {
+		res[i] = items[j]
+	}
+	return res, nil
+}
+
+// Parses a list of indexes, less than n.
+func listIndexes(n int, indexes string) ([]int, error) {
+	var res []int
+	for _, s := range strings.Split(indexes, zenutil.Separator) {
+		i, err := strconv.Atoi(s)
+		if err != nil || i < 0 || i >= n {
+			return nil, fmt.Errorf("invalid selection: %q", indexes)
+		}
+		res = append(res, i)
+	}
+	return res, nil
 }
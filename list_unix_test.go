@@ -0,0 +1,166 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ncruces/zenity/internal/zenutil"
+)
+
+// Install a fake dialog tool that fails if items are passed as arguments,
+// saves its input, and selects the last row, by printing its print column.
+func installFakeListTool(t *testing.T) string {
+	return installFakeTool(t, `#!/bin/sh
+cols=0
+for arg; do
+	case "$arg" in
+	--help) exit 2 ;;
+	--column=*) cols=$((cols+1)) ;;
+	--print-column=*) print=${arg#--print-column=} ;;
+	esac
+done
+tee "$0.input" | awk -v n=$cols -v p=$print 'NR%n==p%n {last=$0} END {print last}'
+`)
+}
+
+func readFakeInput(t *testing.T, dir string) []byte {
+	inputs, _ := filepath.Glob(filepath.Join(dir, "*.input"))
+	if len(inputs) != 1 {
+		t.Fatal("fake tool did not save its input")
+	}
+	input, err := os.ReadFile(inputs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input
+}
+
+func Test_list_stdin(t *testing.T) {
+	dir := installFakeListTool(t)
+
+	items := make([]string, 100_000)
+	for i := range items {
+		items[i] = strconv.Itoa(i)
+	}
+	items = append(items, "two\nlines", "--help")
+
+	got, err := list("", items, options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "--help" {
+		t.Errorf("list() = %q; want %q", got, "--help")
+	}
+
+	input := readFakeInput(t, dir)
+	if want := 2 * len(items); countLines(input) != want {
+		t.Errorf("list() sent %d cells; want %d", countLines(input), want)
+	}
+
+	multi, err := listMultiple("", items, options{defaultItems: []string{"--help"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"--help"}; !reflect.DeepEqual(multi, want) {
+		t.Errorf("listMultiple() = %q; want %q", multi, want)
+	}
+	input = readFakeInput(t, dir)
+	if want := 3 * len(items); countLines(input) != want {
+		t.Errorf("listMultiple() sent %d cells; want %d", countLines(input), want)
+	}
+}
+
+func Test_listTree(t *testing.T) {
+	dir := installFakeListTool(t)
+	tree := []TreeItem{
+		{Name: "prod", Children: []TreeItem{
+			{Name: "eu", Children: []TreeItem{{Name: "web"}, {Name: "db"}}},
+			{Name: "us", Children: []TreeItem{{Name: "web"}}},
+		}},
+		{Name: "dev"},
+	}
+
+	got, err := ListTree("", tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"dev"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListTree() = %q; want %q", got, want)
+	}
+
+	want := strings.Join([]string{
+		"0", "prod", "eu", "web",
+		"1", "", "", "db",
+		"2", "", "us", "web",
+		"3", "dev", "", "",
+	}, "\n") + "\n"
+	if input := readFakeInput(t, dir); string(input) != want {
+		t.Errorf("ListTree() sent %q; want %q", input, want)
+	}
+
+	multi, err := ListTreeMultiple("", tree[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"prod", "us", "web"}}; !reflect.DeepEqual(multi, want) {
+		t.Errorf("ListTreeMultiple() = %q; want %q", multi, want)
+	}
+}
+
+func Test_listItems(t *testing.T) {
+	zenutil.Separator = "|"
+	t.Parallel()
+	items := []string{"a", "b|c", "--help"}
+
+	tests := []struct {
+		indexes string
+		want    []string
+	}{
+		{"0", []string{"a"}},
+		{"1|2", []string{"b|c", "--help"}},
+		{"2|0|1", []string{"--help", "a", "b|c"}},
+		{"3", nil},
+		{"-1", nil},
+		{"a", nil},
+		{"0|", nil},
+	}
+	for _, tt := range tests {
+		got, err := listItems(items, tt.indexes)
+		if !reflect.DeepEqual(got, tt.want) || (err == nil) != (tt.want != nil) {
+			t.Errorf("listItems(%q) = %q, %v; want %q", tt.indexes, got, err, tt.want)
+		}
+	}
+}
+
+func Fuzz_listItems(f *testing.F) {
+	zenutil.Separator = "|"
+	f.Add("0|1")
+	f.Add("1||0")
+	f.Add("+1")
+
+	items := []string{"a", "b"}
+	f.Fuzz(func(t *testing.T, indexes string) {
+		res, err := listItems(items, indexes)
+		if err != nil {
+			return
+		}
+		if len(res) != strings.Count(indexes, "|")+1 {
+			t.Errorf("listItems(%q) = %q", indexes, res)
+		}
+	})
+}
+
+func countLines(data []byte) (n int) {
+	for _, b := range data {
+		if b == '\n' {
+			n++
+		}
+	}
+	return n
+}
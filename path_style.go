@@ -0,0 +1,302 @@
+package zenity
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+)
+
+// PathStyle is an Option that sets the style of the paths
+// passed to (Filename) and returned by (SelectFile*) dialogs.
+//
+// Paths are translated to and from Windows paths,
+// which are what Windows dialogs use.
+// This is useful for programs that are built for Windows,
+// but called with paths from WSL, Cygwin or MSYS.
+// PathStyle is ignored on other platforms.
+//
+// Translation is done in pure Go, without calling wslpath or cygpath.
+// Relative paths only have their separators translated.
+type PathStyle interface {
+	Option
+	// ToWindows translates a path in this style to a Windows path.
+	ToWindows(path string) (string, error)
+	// FromWindows translates a Windows path to this style.
+	FromWindows(path string) (string, error)
+}
+
+// TranslatePath translates a path from one style to another.
+func TranslatePath(path string, from, to PathStyle) (string, error) {
+	path, err := from.ToWindows(path)
+	if err != nil {
+		return "", err
+	}
+	return to.FromWindows(path)
+}
+
+// WindowsPaths is the PathStyle of Windows paths.
+// Forward slashes are translated to backslashes.
+type WindowsPaths struct{}
+
+func (s WindowsPaths) apply(o *options) { o.pathStyle = s }
+
+// ToWindows translates a Windows path with forward slashes to backslashes.
+func (WindowsPaths) ToWindows(path string) (string, error) {
+	return strings.ReplaceAll(path, "/", `\`), nil
+}
+
+// FromWindows returns path.
+func (WindowsPaths) FromWindows(path string) (string, error) {
+	return path, nil
+}
+
+// WSLPaths is the PathStyle of the Windows Subsystem for Linux.
+//
+// Windows drives are mounted as /mnt/c.
+// Other paths are translated to and from \\wsl$\Distro.
+type WSLPaths struct {
+	Distro string // name of the distribution, required for paths outside drive mounts
+	Root   string // where drives are mounted, defaults to /mnt
+}
+
+func (s WSLPaths) apply(o *options) { o.pathStyle = s }
+
+func (s WSLPaths) root() string {
+	if s.Root == "" {
+		return "/mnt"
+	}
+	return strings.TrimSuffix(s.Root, "/")
+}
+
+// ToWindows translates a WSL path to a Windows path.
+func (s WSLPaths) ToWindows(path string) (string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return strings.ReplaceAll(path, "/", `\`), nil
+	}
+	if drive, rest, ok := cutDrive(path, s.root()); ok {
+		return drive + `:\` + strings.ReplaceAll(rest, "/", `\`), nil
+	}
+	if s.Distro == "" {
+		return "", fmt.Errorf("cannot translate path: %s", path)
+	}
+	return `\\wsl$\` + s.Distro + strings.ReplaceAll(path, "/", `\`), nil
+}
+
+// FromWindows translates a Windows path to a WSL path.
+func (s WSLPaths) FromWindows(path string) (string, error) {
+	slash := strings.ReplaceAll(path, `\`, "/")
+	if drive, rest, ok := windowsDrive(slash); ok {
+		return s.root() + "/" + strings.ToLower(drive) + rest, nil
+	}
+	if server, rest, ok := windowsUNC(slash); ok && s.Distro != "" &&
+		(strings.EqualFold(server, "wsl$") || strings.EqualFold(server, "wsl.localhost")) {
+		distro, rest, _ := strings.Cut(rest, "/")
+		if strings.EqualFold(distro, s.Distro) {
+			return "/" + rest, nil
+		}
+	}
+	if isWindowsAbs(slash) {
+		return "", fmt.Errorf("cannot translate path: %s", path)
+	}
+	return slash, nil
+}
+
+// CygwinPaths is the PathStyle of Cygwin and MSYS.
+//
+// Windows drives are mounted as /cygdrive/c (Cygwin), or /c (MSYS).
+// Other paths are translated using a mount table.
+type CygwinPaths struct {
+	Prefix string      // where drives are mounted, defaults to /cygdrive, use / for MSYS
+	Mounts []PathMount // the mount table, see ParseMountTable
+}
+
+// PathMount is a Cygwin or MSYS mount point.
+type PathMount struct {
+	Windows string // the Windows path, like C:\cygwin64
+	POSIX   string // the mount point, like /
+}
+
+func (s CygwinPaths) apply(o *options) { o.pathStyle = s }
+
+func (s CygwinPaths) prefix() string {
+	if s.Prefix == "" {
+		return "/cygdrive"
+	}
+	return strings.TrimSuffix(s.Prefix, "/")
+}
+
+// ToWindows translates a Cygwin or MSYS path to a Windows path.
+func (s CygwinPaths) ToWindows(path string) (string, error) {
+	// Relative and UNC paths.
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+		return strings.ReplaceAll(path, "/", `\`), nil
+	}
+
+	// Find the longest mount point that contains path.
+	var best *PathMount
+	var rest string
+	for i, m := range s.Mounts {
+		if r, ok := cutPath(path, m.POSIX); ok {
+			if best == nil || len(m.POSIX) > len(best.POSIX) {
+				best, rest = &s.Mounts[i], r
+			}
+		}
+	}
+
+	prefix := s.prefix()
+	if drive, r, ok := cutDrive(path, prefix); ok {
+		if best == nil || len(prefix)+2 >= len(strings.TrimSuffix(best.POSIX, "/")) {
+			return drive + `:\` + strings.ReplaceAll(r, "/", `\`), nil
+		}
+	}
+	if best == nil {
+		return "", fmt.Errorf("cannot translate path: %s", path)
+	}
+
+	win := strings.TrimRight(strings.ReplaceAll(best.Windows, "/", `\`), `\`)
+	if rest == "" && len(win) == 2 && win[1] == ':' {
+		return win + `\`, nil
+	}
+	return win + strings.ReplaceAll(rest, "/", `\`), nil
+}
+
+// FromWindows translates a Windows path to a Cygwin or MSYS path.
+func (s CygwinPaths) FromWindows(path string) (string, error) {
+	slash := strings.ReplaceAll(path, `\`, "/")
+	if !isWindowsAbs(slash) {
+		return slash, nil
+	}
+
+	// Find the longest mount whose Windows path contains path.
+	var best *PathMount
+	var rest string
+	for i, m := range s.Mounts {
+		win := strings.ReplaceAll(m.Windows, `\`, "/")
+		if r, ok := cutPathFold(slash, win); ok {
+			if best == nil || len(m.Windows) > len(best.Windows) {
+				best, rest = &s.Mounts[i], r
+			}
+		}
+	}
+	if best != nil {
+		posix := strings.TrimSuffix(best.POSIX, "/")
+		if posix == "" && rest == "" {
+			return "/", nil
+		}
+		return posix + rest, nil
+	}
+
+	if drive, rest, ok := windowsDrive(slash); ok {
+		return s.prefix() + "/" + strings.ToLower(drive) + rest, nil
+	}
+	if strings.HasPrefix(slash, "//") {
+		return slash, nil
+	}
+	return "", fmt.Errorf("cannot translate path: %s", path)
+}
+
+// ParseMountTable parses the output of the Cygwin or MSYS mount command,
+// which has lines like:
+//
+//	C:/cygwin64/bin on /usr/bin type ntfs (binary,auto)
+func ParseMountTable(r io.Reader) ([]PathMount, error) {
+	var res []PathMount
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		win, rest, ok := strings.Cut(line, " on ")
+		if !ok {
+			continue
+		}
+		i := strings.LastIndex(rest, " type ")
+		if i < 0 {
+			continue
+		}
+		res = append(res, PathMount{Windows: win, POSIX: rest[:i]})
+	}
+	return res, scanner.Err()
+}
+
+// Cut a drive mount, like /mnt/c, from the start of a POSIX path.
+func cutDrive(path, prefix string) (drive, rest string, ok bool) {
+	rest, ok = cutPath(path, prefix)
+	if !ok || len(rest) < 2 || rest[0] != '/' || !isDriveLetter(rest[1]) {
+		return "", "", false
+	}
+	if len(rest) > 2 && rest[2] != '/' {
+		return "", "", false
+	}
+	drive, rest = strings.ToUpper(rest[1:2]), rest[2:]
+	return drive, strings.TrimPrefix(rest, "/"), true
+}
+
+// Cut a directory from the start of path, on a path component boundary.
+// The remainder is empty, or starts with a slash.
+func cutPath(path, dir string) (string, bool) {
+	dir = strings.TrimSuffix(dir, "/")
+	rest, ok := strings.CutPrefix(path, dir)
+	if ok && (rest == "" || rest[0] == '/') {
+		return strings.TrimSuffix(rest, "/"), true
+	}
+	return "", false
+}
+
+// Like cutPath, but case-insensitive, for Windows paths.
+func cutPathFold(path, dir string) (string, bool) {
+	dir = strings.TrimSuffix(dir, "/")
+	if len(path) < len(dir) || !strings.EqualFold(path[:len(dir)], dir) {
+		return "", false
+	}
+	rest := path[len(dir):]
+	if rest == "" || rest[0] == '/' {
+		return strings.TrimSuffix(rest, "/"), true
+	}
+	return "", false
+}
+
+// Split a Windows path, with forward slashes, like C:/dir into C and /dir.
+func windowsDrive(path string) (drive, rest string, ok bool) {
+	if len(path) < 2 || !isDriveLetter(path[0]) || path[1] != ':' {
+		return "", "", false
+	}
+	if len(path) > 2 && path[2] != '/' {
+		return "", "", false
+	}
+	rest = strings.TrimSuffix(path[2:], "/")
+	return strings.ToUpper(path[:1]), rest, true
+}
+
+// Split a Windows UNC path, with forward slashes, like //server/share into server and share.
+func windowsUNC(path string) (server, rest string, ok bool) {
+	path, ok = strings.CutPrefix(path, "//")
+	if !ok {
+		return "", "", false
+	}
+	server, rest, _ = strings.Cut(path, "/")
+	return server, rest, server != ""
+}
+
+func isWindowsAbs(path string) bool {
+	_, _, drive := windowsDrive(path)
+	return drive || strings.HasPrefix(path, "//")
+}
+
+func isDriveLetter(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+func ingestPath(opts options, path string) (string, error) {
+	if opts.pathStyle == nil || runtime.GOOS != "windows" || path == "" {
+		return path, nil
+	}
+	return opts.pathStyle.ToWindows(path)
+}
+
+func egestPath(opts options, path string) (string, error) {
+	if opts.pathStyle == nil || runtime.GOOS != "windows" || path == "" {
+		return path, nil
+	}
+	return opts.pathStyle.FromWindows(path)
+}
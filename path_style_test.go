@@ -0,0 +1,185 @@
+package zenity
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWSLPaths(t *testing.T) {
+	t.Parallel()
+	style := WSLPaths{Distro: "Ubuntu"}
+	tests := []struct {
+		posix string
+		win   string
+	}{
+		{"/mnt/c", `C:\`},
+		{"/mnt/c/Users/me", `C:\Users\me`},
+		{"/mnt/d/a b/c.txt", `D:\a b\c.txt`},
+		{"/home/me/file.txt", `\\wsl$\Ubuntu\home\me\file.txt`},
+		{"/mnt/cd/file.txt", `\\wsl$\Ubuntu\mnt\cd\file.txt`},
+		{"dir/file.txt", `dir\file.txt`},
+	}
+	for _, tt := range tests {
+		if got, err := style.ToWindows(tt.posix); err != nil || got != tt.win {
+			t.Errorf("ToWindows(%q) = %q, %v; want %q", tt.posix, got, err, tt.win)
+		}
+		if got, err := style.FromWindows(tt.win); err != nil || got != tt.posix {
+			t.Errorf("FromWindows(%q) = %q, %v; want %q", tt.win, got, err, tt.posix)
+		}
+	}
+}
+
+func TestWSLPaths_fromWindows(t *testing.T) {
+	t.Parallel()
+	style := WSLPaths{Distro: "Ubuntu", Root: "/win/"}
+	tests := []struct {
+		win   string
+		posix string
+	}{
+		{`c:\Users\`, "/win/c/Users"},
+		{`C:/Users/me`, "/win/c/Users/me"},
+		{`\\wsl.localhost\ubuntu\etc`, "/etc"},
+		{`\\WSL$\Ubuntu`, "/"},
+	}
+	for _, tt := range tests {
+		if got, err := style.FromWindows(tt.win); err != nil || got != tt.posix {
+			t.Errorf("FromWindows(%q) = %q, %v; want %q", tt.win, got, err, tt.posix)
+		}
+	}
+}
+
+func TestWSLPaths_errors(t *testing.T) {
+	t.Parallel()
+	if got, err := (WSLPaths{}).ToWindows("/home/me"); err == nil {
+		t.Errorf("ToWindows() = %q; want error", got)
+	}
+	for _, path := range []string{`\\server\share`, `\\wsl$\Debian\etc`} {
+		if got, err := (WSLPaths{Distro: "Ubuntu"}).FromWindows(path); err == nil {
+			t.Errorf("FromWindows(%q) = %q; want error", path, got)
+		}
+	}
+}
+
+const testMountTable = `C:/cygwin64/bin on /usr/bin type ntfs (binary,auto)
+C:/cygwin64/lib on /usr/lib type ntfs (binary,auto)
+C:/cygwin64 on / type ntfs (binary,auto)
+C: on /cygdrive/c type ntfs (binary,posix=0,user,noumount,auto)
+D:/Program Files on /opt/pf type ntfs (binary,user)
+`
+
+func TestParseMountTable(t *testing.T) {
+	t.Parallel()
+	mounts, err := ParseMountTable(strings.NewReader(testMountTable))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mounts) != 5 {
+		t.Fatalf("ParseMountTable() = %v", mounts)
+	}
+	if got := mounts[4]; got != (PathMount{`D:/Program Files`, "/opt/pf"}) {
+		t.Errorf("ParseMountTable()[4] = %v", got)
+	}
+}
+
+func TestCygwinPaths(t *testing.T) {
+	t.Parallel()
+	mounts, _ := ParseMountTable(strings.NewReader(testMountTable))
+	style := CygwinPaths{Mounts: mounts}
+	tests := []struct {
+		posix string
+		win   string
+	}{
+		{"/", `C:\cygwin64`},
+		{"/home/me", `C:\cygwin64\home\me`},
+		{"/usr/bin/ls", `C:\cygwin64\bin\ls`},
+		{"/cygdrive/c", `C:\`},
+		{"/cygdrive/c/Users", `C:\Users`},
+		{"/cygdrive/e/data", `E:\data`},
+		{"/opt/pf/app", `D:\Program Files\app`},
+		{"//server/share/file", `\\server\share\file`},
+		{"dir/file.txt", `dir\file.txt`},
+	}
+	for _, tt := range tests {
+		if got, err := style.ToWindows(tt.posix); err != nil || got != tt.win {
+			t.Errorf("ToWindows(%q) = %q, %v; want %q", tt.posix, got, err, tt.win)
+		}
+		if got, err := style.FromWindows(tt.win); err != nil || got != tt.posix {
+			t.Errorf("FromWindows(%q) = %q, %v; want %q", tt.win, got, err, tt.posix)
+		}
+	}
+}
+
+func TestCygwinPaths_msys(t *testing.T) {
+	t.Parallel()
+	style := CygwinPaths{Prefix: "/", Mounts: []PathMount{{`C:\msys64`, "/"}}}
+	tests := []struct {
+		posix string
+		win   string
+	}{
+		{"/", `C:\msys64`},
+		{"/home/me", `C:\msys64\home\me`},
+		{"/c", `C:\`},
+		{"/c/Users/me", `C:\Users\me`},
+		{"/d/data", `D:\data`},
+	}
+	for _, tt := range tests {
+		if got, err := style.ToWindows(tt.posix); err != nil || got != tt.win {
+			t.Errorf("ToWindows(%q) = %q, %v; want %q", tt.posix, got, err, tt.win)
+		}
+		if got, err := style.FromWindows(tt.win); err != nil || got != tt.posix {
+			t.Errorf("FromWindows(%q) = %q, %v; want %q", tt.win, got, err, tt.posix)
+		}
+	}
+}
+
+func TestCygwinPaths_errors(t *testing.T) {
+	t.Parallel()
+	if got, err := (CygwinPaths{}).ToWindows("/home/me"); err == nil {
+		t.Errorf("ToWindows() = %q; want error", got)
+	}
+}
+
+func TestTranslatePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path     string
+		from, to PathStyle
+		want     string
+	}{
+		{"/mnt/c/Users", WSLPaths{}, CygwinPaths{}, "/cygdrive/c/Users"},
+		{"/cygdrive/c/Users", CygwinPaths{}, WSLPaths{}, "/mnt/c/Users"},
+		{"/c/Users", CygwinPaths{Prefix: "/"}, WindowsPaths{}, `C:\Users`},
+		{"C:/Users", WindowsPaths{}, WSLPaths{}, "/mnt/c/Users"},
+	}
+	for _, tt := range tests {
+		if got, err := TranslatePath(tt.path, tt.from, tt.to); err != nil || got != tt.want {
+			t.Errorf("TranslatePath(%q) = %q, %v; want %q", tt.path, got, err, tt.want)
+		}
+	}
+}
+
+func Test_nativeOptions(t *testing.T) {
+	t.Parallel()
+	list := []Option{WSLPaths{}, Filename("/mnt/c/Users/a.txt")}
+	opts := applyOptions(list)
+
+	native, err := nativeOptions(opts, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := applyOptions(native)
+	if got.pathStyle != nil {
+		t.Error("nativeOptions() kept the path style")
+	}
+	want := "/mnt/c/Users/a.txt"
+	if runtime.GOOS == "windows" {
+		want = `C:\Users\a.txt`
+	}
+	if got.filename != want {
+		t.Errorf("nativeOptions() filename = %q; want %q", got.filename, want)
+	}
+	if len(list) != 2 {
+		t.Error("nativeOptions() modified its argument")
+	}
+}
@@ -1,13 +1,26 @@
 package zenity
 
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+)
+
 // Progress displays the progress indication dialog.
+// The returned ProgressDialog is also a ProgressMonitor.
 //
 // Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
-// Icon, WindowIcon, Attach, Modal, MaxValue, Pulsate, NoCancel, TimeRemaining.
+// Icon, WindowIcon, Attach, Modal, MaxValue, Pulsate, NoCancel, TimeRemaining,
+// KeepOpen.
 //
 // May return: ErrUnsupported.
 func Progress(options ...Option) (ProgressDialog, error) {
-	return progress(applyOptions(options))
+	dlg, err := newProgress(applyOptions(options))
+	if err != nil {
+		return nil, err
+	}
+	return dlg, nil
 }
 
 // ProgressDialog allows you to interact with the progress indication dialog.
@@ -31,6 +44,87 @@ type ProgressDialog interface {
 	Done() <-chan struct{}
 }
 
+// ProgressMonitor allows you to track the stages of a task,
+// and to know why the progress indication dialog was closed.
+//
+// It is separate from ProgressDialog, so that existing implementations
+// of ProgressDialog remain valid. Use a type assertion to get it:
+//
+//	mon := dlg.(zenity.ProgressMonitor)
+type ProgressMonitor interface {
+	ProgressDialog
+
+	// Sub returns a ProgressTracker for a stage of the task
+	// that accounts for weight units of MaxValue,
+	// and that requires max units of work in total.
+	Sub(weight, max int) ProgressTracker
+
+	// Err returns nil if Done is not yet closed.
+	// If Done is closed, Err returns why the dialog was closed:
+	// nil if it was closed normally, ErrCanceled, ErrExtraButton,
+	// the Context error, or the error that made the dialog fail.
+	Err() error
+
+	// Events returns a channel that receives events as they happen.
+	// The channel is closed after the dialog is closed,
+	// and the final event is never dropped.
+	// Other events may be dropped if the channel is not drained.
+	Events() <-chan ProgressEvent
+}
+
+// ProgressEvent is an event that happened to a progress indication dialog.
+type ProgressEvent int
+
+// The progress indication dialog events.
+const (
+	ProgressClosed      ProgressEvent = iota // the dialog was closed normally
+	ProgressCanceled                         // the cancel button was pressed
+	ProgressExtraButton                      // the extra button was pressed
+	ProgressAutoClosed                       // the dialog was dismissed by AutoClose
+	ProgressExpired                          // the Context was done
+	ProgressFailed                           // the dialog failed
+)
+
+func (e ProgressEvent) String() string {
+	switch e {
+	case ProgressClosed:
+		return "closed"
+	case ProgressCanceled:
+		return "canceled"
+	case ProgressExtraButton:
+		return "extra button"
+	case ProgressAutoClosed:
+		return "auto closed"
+	case ProgressExpired:
+		return "expired"
+	case ProgressFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ProgressTracker allows you to report progress for a stage of a task.
+//
+// Progress reported by a stage is rolled up proportionally into its parent,
+// and added to the value set on the parent.
+// A ProgressTracker is safe for concurrent use by multiple goroutines.
+type ProgressTracker interface {
+	// Text sets the dialog text.
+	Text(string) error
+
+	// Value sets how much of the stage has been completed.
+	Value(int) error
+
+	// MaxValue gets how much work the stage requires in total.
+	MaxValue() int
+
+	// Sub returns a ProgressTracker for a stage of this stage
+	// that accounts for weight units of MaxValue,
+	// and that requires max units of work in total.
+	Sub(weight, max int) ProgressTracker
+}
+
 // MaxValue returns an Option to set the maximum value.
 // The default maximum value is 100.
 func MaxValue(value int) Option {
@@ -56,3 +150,196 @@ func AutoClose() Option {
 func TimeRemaining() Option {
 	return funcOption(func(o *options) { o.timeRemaining = true })
 }
+
+// KeepOpen returns an Option to keep the dialog open when the extra button
+// is pressed (Windows only).
+// Button presses are reported as ProgressExtraButton events.
+func KeepOpen() Option {
+	return funcOption(func(o *options) { o.keepOpen = true })
+}
+
+// progressBackend is implemented by the platform progress dialogs.
+type progressBackend interface {
+	Text(string) error
+	Value(int) error
+	MaxValue() int
+	Complete() error
+	Close() error
+	Done() <-chan struct{}
+	Err() error
+}
+
+// progressTracker is a node in a tree of stages,
+// the root of which is a progress dialog.
+type progressTracker struct {
+	progressBackend
+	mtx    *sync.Mutex
+	parent *progressTracker
+	weight float64 // in units of the parent
+	max    int
+	value  int
+	sub    float64 // sum of the parts of all children
+	part   float64 // current part of the parent
+	last   int     // last value sent to the dialog (root only)
+
+	events     chan ProgressEvent // root only
+	autoClose  bool
+	autoClosed bool
+}
+
+func newProgress(opts options) (*progressTracker, error) {
+	events := make(chan ProgressEvent, 16)
+
+	var extra func()
+	if opts.keepOpen {
+		extra = func() {
+			select {
+			case events <- ProgressExtraButton:
+			default:
+			}
+		}
+	}
+
+	dlg, err := progress(opts, extra)
+	if err != nil {
+		return nil, err
+	}
+	t := newProgressTracker(dlg)
+	t.events = events
+	t.autoClose = opts.autoClose
+	go t.watch()
+	return t, nil
+}
+
+func newProgressTracker(dlg progressBackend) *progressTracker {
+	return &progressTracker{
+		progressBackend: dlg,
+		mtx:             new(sync.Mutex),
+		max:             dlg.MaxValue(),
+		last:            -1,
+	}
+}
+
+func (t *progressTracker) Value(value int) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.value = value
+	return t.update(t.parent == nil)
+}
+
+// Complete goes through the tracker, so AutoClose is reported as such.
+func (t *progressTracker) Complete() error {
+	t.mtx.Lock()
+	if t.autoClose {
+		t.autoClosed = true
+	}
+	t.mtx.Unlock()
+	return t.progressBackend.Complete()
+}
+
+func (t *progressTracker) MaxValue() int {
+	return t.max
+}
+
+func (t *progressTracker) Events() <-chan ProgressEvent {
+	return t.events
+}
+
+func (t *progressTracker) Sub(weight, max int) ProgressTracker {
+	if max <= 0 {
+		max = 100
+	}
+	return &progressTracker{
+		progressBackend: t.progressBackend,
+		mtx:             t.mtx,
+		parent:          t,
+		weight:          float64(weight),
+		max:             max,
+	}
+}
+
+// update rolls up the value of t into the dialog.
+// Unless forced, the dialog is only updated if its value changed.
+func (t *progressTracker) update(force bool) error {
+	for ; t.parent != nil; t = t.parent {
+		part := t.weight * t.fraction()
+		if part == t.part {
+			return t.closed()
+		}
+		t.parent.sub += part - t.part
+		t.part = part
+	}
+
+	if t.max < 0 && !force {
+		return t.closed()
+	}
+	value := t.value + int(math.Round(t.sub))
+	if value == t.last && !force {
+		return t.closed()
+	}
+	t.last = value
+	if t.autoClose && value >= t.max {
+		t.autoClosed = true
+	}
+	return t.progressBackend.Value(value)
+}
+
+// fraction returns how much of the stage has been completed, from 0 to 1.
+func (t *progressTracker) fraction() float64 {
+	f := (float64(t.value) + t.sub) / float64(t.max)
+	return min(max(f, 0), 1)
+}
+
+// closed reports the dialog error, if the dialog is closed.
+func (t *progressTracker) closed() error {
+	select {
+	default:
+		return nil
+	case <-t.Done():
+	}
+	for t.parent != nil {
+		t = t.parent
+	}
+	// No value was sent to the dialog.
+	if t.last < 0 {
+		return t.Err()
+	}
+	return t.progressBackend.Value(t.last)
+}
+
+// watch sends the final event, once the dialog is closed.
+func (t *progressTracker) watch() {
+	<-t.Done()
+
+	var event ProgressEvent
+	switch err := t.Err(); {
+	case err == nil:
+		t.mtx.Lock()
+		if t.autoClosed {
+			event = ProgressAutoClosed
+		} else {
+			event = ProgressClosed
+		}
+		t.mtx.Unlock()
+	case err == ErrCanceled:
+		event = ProgressCanceled
+	case err == ErrExtraButton:
+		event = ProgressExtraButton
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		event = ProgressExpired
+	default:
+		event = ProgressFailed
+	}
+
+	// Make room for the final event, if needed.
+	select {
+	case t.events <- event:
+	default:
+		select {
+		case <-t.events:
+		default:
+		}
+		t.events <- event
+	}
+	close(t.events)
+}
@@ -0,0 +1,215 @@
+package zenity
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ncruces/zenity/internal/zenutil"
+)
+
+// ProgressCmd starts cmd and waits for it to complete,
+// while displaying the progress indication dialog.
+//
+// The standard output and error of cmd are split into lines,
+// and each line is passed to parse.
+// Lines that report progress update the progress bar,
+// other lines update the dialog text.
+// If parse is nil, ParsePercent is used.
+//
+// If the dialog is closed before cmd completes,
+// the process group of cmd is killed (the process on Windows).
+// On Unix, since cmd runs in its own process group,
+// signals from the terminal, like Ctrl-C, don't reach it:
+// cancel ctx to stop it.
+//
+// After cmd exits, its output is read for at most another second,
+// since processes it started may keep the output open.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// Icon, WindowIcon, Attach, Modal, Pulsate, NoCancel, TimeRemaining.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported,
+// and any error returned by cmd.Wait.
+func ProgressCmd(ctx context.Context, cmd *exec.Cmd, parse ProgressParser, options ...Option) error {
+	opts := applyOptions(options)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	opts.ctx = ctx
+	if opts.maxValue >= 0 {
+		opts.maxValue = 1000
+	}
+
+	dlg, err := newProgress(opts)
+	if err != nil {
+		return err
+	}
+	return progressCmd(dlg, cmd, parse)
+}
+
+// ProgressParser parses a line of command output.
+// If the line reports progress, it returns how much of the task has been
+// completed, from 0 to 1, and ok set to true.
+type ProgressParser func(line string) (done float64, ok bool)
+
+var (
+	percentRegex = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*%`)
+	ratioRegex   = regexp.MustCompile(`(\d+)\s*/\s*(\d+)`)
+)
+
+// ParsePercent is a ProgressParser that reports the last percentage
+// found in a line, like "45%" or "45.5 %".
+func ParsePercent(line string) (done float64, ok bool) {
+	m := percentRegex.FindAllStringSubmatch(line, -1)
+	if m == nil {
+		return 0, false
+	}
+	s := strings.ReplaceAll(m[len(m)-1][1], ",", ".")
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return min(f/100, 1), true
+}
+
+// ParseRatio is a ProgressParser that reports the last ratio
+// found in a line, like "3/4" (N out of M).
+// Ratios must be delimited by spaces, brackets or punctuation,
+// and N can't exceed M, so dates and paths, like 2024/10, are ignored.
+func ParseRatio(line string) (done float64, ok bool) {
+	for _, m := range ratioRegex.FindAllStringSubmatchIndex(line, -1) {
+		if !ratioDelimited(line, m[0], m[1]) {
+			continue
+		}
+		n, err := strconv.ParseFloat(line[m[2]:m[3]], 64)
+		if err != nil {
+			continue
+		}
+		d, err := strconv.ParseFloat(line[m[4]:m[5]], 64)
+		if err != nil || d == 0 || n > d {
+			continue
+		}
+		done, ok = n/d, true
+	}
+	return done, ok
+}
+
+// Reports whether line[start:end] stands alone, rather than being part of
+// a word, a number, a date, or a path.
+func ratioDelimited(line string, start, end int) bool {
+	return (start == 0 || strings.IndexByte(" \t([", line[start-1]) >= 0) &&
+		(end == len(line) || strings.IndexByte(" \t)],;:", line[end]) >= 0)
+}
+
+// How long to keep reading output after the command exits.
+// Like exec.Cmd.WaitDelay, this bounds the wait for processes
+// that inherited the output, and may never close it.
+const progressWaitDelay = time.Second
+
+func progressCmd(dlg ProgressMonitor, cmd *exec.Cmd, parse ProgressParser) error {
+	if parse == nil {
+		parse = ParsePercent
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		dlg.Close()
+		return err
+	}
+	defer r.Close()
+	cmd.Stdout = teeWriter(cmd.Stdout, w)
+	cmd.Stderr = teeWriter(cmd.Stderr, w)
+
+	zenutil.NewProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		w.Close()
+		dlg.Close()
+		return err
+	}
+
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		max := float64(dlg.MaxValue())
+		scanner := bufio.NewScanner(r)
+		scanner.Split(scanProgressLines)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			if done, ok := parse(line); ok {
+				dlg.Value(int(math.Round(max * done)))
+			} else {
+				dlg.Text(line)
+			}
+		}
+		io.Copy(io.Discard, r)
+	}()
+
+	exited := make(chan struct{})
+	go func() {
+		select {
+		case <-dlg.Done():
+			if dlg.Err() != nil {
+				zenutil.KillProcessGroup(cmd)
+			}
+		case <-exited:
+		}
+	}()
+
+	err = cmd.Wait()
+	close(exited)
+	w.Close()
+	// Processes started by cmd may keep the pipe open.
+	select {
+	case <-read:
+	case <-time.After(progressWaitDelay):
+		r.Close()
+		<-read
+	}
+
+	derr := dlg.Err()
+	if derr == nil {
+		derr = dlg.Close()
+	}
+	if derr != nil && err != nil {
+		return errors.Join(derr, err)
+	}
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+func teeWriter(w io.Writer, pipe *os.File) io.Writer {
+	if w == nil {
+		return pipe
+	}
+	return io.MultiWriter(w, pipe)
+}
+
+// scanProgressLines splits on both carriage returns and line feeds,
+// as progress is often reported by rewriting the current line.
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
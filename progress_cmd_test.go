@@ -0,0 +1,149 @@
+package zenity
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePercent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		line string
+		want float64
+		ok   bool
+	}{
+		{"", 0, false},
+		{"copying", 0, false},
+		{"45%", 0.45, true},
+		{"45.5 %", 0.455, true},
+		{"45,5%", 0.455, true},
+		{"######   100.0%", 1, true},
+		{"  1,234,567  45%  1.23MB/s    0:00:10", 0.45, true},
+		{"10% done, 20% read", 0.2, true},
+		{"150%", 1, true},
+	}
+	for _, tt := range tests {
+		got, ok := ParsePercent(tt.line)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParsePercent(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseRatio(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		line string
+		want float64
+		ok   bool
+	}{
+		{"", 0, false},
+		{"copying", 0, false},
+		{"1/4", 0.25, true},
+		{"[3 / 4] building", 0.75, true},
+		{"1/0", 0, false},
+		{"step 1/2, file 3/4", 0.75, true},
+		{"5/4", 0, false},
+		{"2024/10", 0, false},
+		{"2024/10/01 copied 1/2", 0.5, true},
+		{"/usr/lib/1/2", 0, false},
+		{"v1.1/2", 0, false},
+		{"(1/2)", 0.5, true},
+	}
+	for _, tt := range tests {
+		got, ok := ParseRatio(tt.line)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseRatio(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func Test_scanProgressLines(t *testing.T) {
+	t.Parallel()
+	scanner := bufio.NewScanner(strings.NewReader("a\r\nb\rc\nd"))
+	scanner.Split(scanProgressLines)
+
+	var got []string
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+	if want := []string{"a", "", "b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("scanProgressLines() = %q; want %q", got, want)
+	}
+}
+
+func Test_progressCmd(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping: no shell")
+	}
+	t.Parallel()
+	dlg := newFakeProgress(100)
+
+	cmd := exec.Command("sh", "-c", `echo 50%; echo copying >&2; printf '75%%\r100%%'; exit 3`)
+	err := progressCmd(newProgressTracker(dlg), cmd, nil)
+
+	var eerr *exec.ExitError
+	if !errors.As(err, &eerr) || eerr.ExitCode() != 3 {
+		t.Errorf("progressCmd() = %v; want exit status 3", err)
+	}
+	if want := []int{50, 75, 100}; !reflect.DeepEqual(dlg.values, want) {
+		t.Errorf("progressCmd() values = %v; want %v", dlg.values, want)
+	}
+	if dlg.text != "copying" {
+		t.Errorf("progressCmd() text = %q; want %q", dlg.text, "copying")
+	}
+}
+
+func Test_progressCmd_background(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping: no shell")
+	}
+	t.Parallel()
+	dlg := newFakeProgress(100)
+
+	// The background process inherits the output pipe, and keeps it open.
+	cmd := exec.Command("sh", "-c", `sleep 10 & echo 50%`)
+	start := time.Now()
+	if err := progressCmd(newProgressTracker(dlg), cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("progressCmd() took %v", d)
+	}
+	if want := []int{50}; !reflect.DeepEqual(dlg.values, want) {
+		t.Errorf("progressCmd() values = %v; want %v", dlg.values, want)
+	}
+}
+
+func Test_progressCmd_canceled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping: no shell")
+	}
+	t.Parallel()
+	dlg := newFakeProgress(100)
+	dlg.err = ErrCanceled
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", `sleep 60 & echo started; wait`)
+	cmd.WaitDelay = time.Second
+	go func() {
+		time.Sleep(time.Second / 5)
+		dlg.Close()
+	}()
+
+	err := progressCmd(newProgressTracker(dlg), cmd, nil)
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("progressCmd() = %v; want %v", err, ErrCanceled)
+	}
+	if ctx.Err() != nil {
+		t.Error("progressCmd() did not kill the process group")
+	}
+}
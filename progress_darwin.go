@@ -6,7 +6,7 @@ import (
 	"github.com/ncruces/zenity/internal/zenutil"
 )
 
-func progress(opts options) (ProgressDialog, error) {
+func progress(opts options, extra func()) (progressBackend, error) {
 	if opts.extraButton != nil {
 		return nil, fmt.Errorf("%w: extra button", ErrUnsupported)
 	}
@@ -0,0 +1,228 @@
+package zenity
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MultiProgress displays a progress indication dialog with multiple bars.
+//
+// On Unix, if yad is installed, each bar is shown separately.
+// Since yad can't change its bars, yad is restarted whenever bars are added,
+// removed, or start or stop pulsating, and bar names can't contain colons.
+// Otherwise, bars are combined into a single progress bar,
+// and their names and texts are rendered as the dialog text.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel,
+// WindowIcon, Attach, Modal, NoCancel.
+//
+// Attach, Display and ClassHint are ignored if yad is used.
+//
+// May return: ErrUnsupported.
+func MultiProgress(options ...Option) (MultiProgressDialog, error) {
+	view, err := multiProgress(applyOptions(options))
+	if err != nil {
+		return nil, err
+	}
+	return &multiProgressDialog{view: view}, nil
+}
+
+// MultiProgressDialog allows you to interact with the multi-bar progress
+// indication dialog.
+//
+// A single cancel closes the dialog, after which all methods of the dialog,
+// and of its bars, return the reason the dialog was closed.
+type MultiProgressDialog interface {
+	// Add adds a progress bar that requires max units of work in total.
+	Add(name string, max int) (ProgressBar, error)
+
+	// Close closes the dialog.
+	Close() error
+
+	// Done returns a channel that is closed when the dialog is closed.
+	Done() <-chan struct{}
+
+	// Err returns nil if Done is not yet closed.
+	// If Done is closed, Err returns why the dialog was closed.
+	Err() error
+}
+
+// ProgressBar allows you to interact with a bar of the multi-bar progress
+// indication dialog.
+type ProgressBar interface {
+	// Text sets the bar text.
+	Text(string) error
+
+	// Value sets how much of the work has been completed.
+	Value(int) error
+
+	// MaxValue gets how much work is required in total.
+	MaxValue() int
+
+	// Pulsate sets whether the bar pulsates.
+	Pulsate(bool) error
+
+	// Remove removes the bar from the dialog.
+	// Changes to a removed bar are not displayed.
+	Remove() error
+}
+
+// multiProgressView displays the bars of a multi-bar progress dialog.
+type multiProgressView interface {
+	// update displays bars, after bar is changed.
+	// If bar is nil, bars were added, removed or changed kind.
+	update(bars []*progressBar, bar *progressBar) error
+	Close() error
+	Done() <-chan struct{}
+	Err() error
+}
+
+type multiProgressDialog struct {
+	mtx  sync.Mutex
+	view multiProgressView
+	bars []*progressBar
+}
+
+type progressBar struct {
+	dlg   *multiProgressDialog
+	name  string
+	text  string
+	value int
+	max   int
+	pulse bool
+}
+
+func (d *multiProgressDialog) Add(name string, max int) (ProgressBar, error) {
+	if max <= 0 {
+		max = 100
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if err := d.view.Err(); err != nil {
+		return nil, err
+	}
+	bar := &progressBar{dlg: d, name: name, max: max}
+	d.bars = append(d.bars, bar)
+	if err := d.view.update(d.bars, nil); err != nil {
+		d.bars = d.bars[:len(d.bars)-1]
+		return nil, err
+	}
+	return bar, nil
+}
+
+func (d *multiProgressDialog) Close() error {
+	return d.view.Close()
+}
+
+func (d *multiProgressDialog) Done() <-chan struct{} {
+	return d.view.Done()
+}
+
+func (d *multiProgressDialog) Err() error {
+	return d.view.Err()
+}
+
+func (b *progressBar) Text(text string) error {
+	b.dlg.mtx.Lock()
+	defer b.dlg.mtx.Unlock()
+	b.text = text
+	return b.update(b)
+}
+
+func (b *progressBar) Value(value int) error {
+	b.dlg.mtx.Lock()
+	defer b.dlg.mtx.Unlock()
+	b.value = value
+	return b.update(b)
+}
+
+func (b *progressBar) MaxValue() int {
+	return b.max
+}
+
+func (b *progressBar) Pulsate(pulse bool) error {
+	b.dlg.mtx.Lock()
+	defer b.dlg.mtx.Unlock()
+	if b.pulse == pulse {
+		return b.dlg.view.Err()
+	}
+	b.pulse = pulse
+	return b.update(nil)
+}
+
+func (b *progressBar) Remove() error {
+	b.dlg.mtx.Lock()
+	defer b.dlg.mtx.Unlock()
+	i := slices.Index(b.dlg.bars, b)
+	if i < 0 {
+		return b.dlg.view.Err()
+	}
+	b.dlg.bars = slices.Delete(b.dlg.bars, i, i+1)
+	return b.dlg.view.update(b.dlg.bars, nil)
+}
+
+// update displays the change to b, unless b was removed.
+// The dialog mutex must be held.
+func (b *progressBar) update(bar *progressBar) error {
+	if !slices.Contains(b.dlg.bars, b) {
+		return b.dlg.view.Err()
+	}
+	return b.dlg.view.update(b.dlg.bars, bar)
+}
+
+// percent returns how much of the work has been completed, from 0 to 100.
+func (b *progressBar) percent() float64 {
+	return min(max(100*float64(b.value)/float64(b.max), 0), 100)
+}
+
+// compositeProgress renders all bars into a single progress dialog.
+type compositeProgress struct {
+	progressBackend
+}
+
+func newCompositeProgress(opts options) (multiProgressView, error) {
+	opts.maxValue = 1000
+	opts.autoClose = false
+	opts.extraButton = nil
+	dlg, err := progress(opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	return compositeProgress{dlg}, nil
+}
+
+func (c compositeProgress) update(bars []*progressBar, _ *progressBar) error {
+	text, value := compositeText(bars)
+	if err := c.Text(text); err != nil {
+		return err
+	}
+	return c.Value(int(math.Round(10 * value)))
+}
+
+// compositeText renders a line of text for each bar,
+// and the average percentage of all bars that do not pulsate.
+func compositeText(bars []*progressBar) (text string, percent float64) {
+	var n int
+	var lines []string
+	for _, b := range bars {
+		var line strings.Builder
+		line.WriteString(b.name)
+		if b.text != "" {
+			line.WriteString(": ")
+			line.WriteString(b.text)
+		}
+		if !b.pulse {
+			n++
+			percent += b.percent()
+			fmt.Fprintf(&line, " (%.0f%%)", math.Floor(b.percent()))
+		}
+		lines = append(lines, line.String())
+	}
+	if n > 0 {
+		percent /= float64(n)
+	}
+	return strings.Join(lines, "\n"), percent
+}
@@ -0,0 +1,115 @@
+package zenity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_compositeText(t *testing.T) {
+	t.Parallel()
+	bars := []*progressBar{
+		{name: "one", max: 100, value: 50},
+		{name: "two", max: 10, value: 10, text: "done"},
+		{name: "three", pulse: true, text: "waiting"},
+	}
+
+	text, percent := compositeText(bars)
+	if want := "one (50%)\ntwo: done (100%)\nthree: waiting"; text != want {
+		t.Errorf("compositeText() = %q; want %q", text, want)
+	}
+	if percent != 75 {
+		t.Errorf("compositeText() = %v; want %v", percent, 75)
+	}
+}
+
+func Test_multiProgressDialog(t *testing.T) {
+	t.Parallel()
+	view := &fakeMultiProgress{}
+	dlg := &multiProgressDialog{view: view}
+
+	one, _ := dlg.Add("one", 0)
+	two, _ := dlg.Add("two", 10)
+	one.Value(50)
+	two.Text("text")
+	two.Pulsate(true)
+	two.Pulsate(true)
+	one.Remove()
+	one.Remove()
+	one.Value(75)
+	one.Text("removed")
+	one.Pulsate(true)
+
+	want := []string{"add", "add", "one", "two", "pulsate", "remove"}
+	if !reflect.DeepEqual(view.calls, want) {
+		t.Errorf("multiProgressDialog calls = %v; want %v", view.calls, want)
+	}
+	if len(dlg.bars) != 1 || dlg.bars[0] != two {
+		t.Errorf("multiProgressDialog bars = %v; want [two]", dlg.bars)
+	}
+	if got := one.MaxValue(); got != 100 {
+		t.Errorf("ProgressBar.MaxValue() = %v; want 100", got)
+	}
+}
+
+func Test_compositeProgress(t *testing.T) {
+	t.Parallel()
+	fake := newFakeProgress(1000)
+	dlg := &multiProgressDialog{view: compositeProgress{fake}}
+
+	one, _ := dlg.Add("one", 4)
+	one.Value(1)
+	dlg.Close()
+
+	if want := []int{0, 250}; !reflect.DeepEqual(fake.values, want) {
+		t.Errorf("compositeProgress values = %v; want %v", fake.values, want)
+	}
+	if want := "one (25%)"; fake.text != want {
+		t.Errorf("compositeProgress text = %q; want %q", fake.text, want)
+	}
+	if err := one.Value(2); err != ErrCanceled {
+		t.Errorf("ProgressBar.Value() = %v; want %v", err, ErrCanceled)
+	}
+}
+
+func Test_multiProgressDialog_addError(t *testing.T) {
+	t.Parallel()
+	view := &fakeMultiProgress{}
+	dlg := &multiProgressDialog{view: view}
+
+	one, _ := dlg.Add("one", 0)
+	view.err = ErrUnsupported
+	if bar, err := dlg.Add("two", 0); bar != nil || err != ErrUnsupported {
+		t.Errorf("multiProgressDialog.Add() = %v, %v; want %v", bar, err, ErrUnsupported)
+	}
+	if len(dlg.bars) != 1 || dlg.bars[0] != one {
+		t.Errorf("multiProgressDialog bars = %v; want [one]", dlg.bars)
+	}
+}
+
+type fakeMultiProgress struct {
+	calls []string
+	count int
+	err   error
+}
+
+func (v *fakeMultiProgress) update(bars []*progressBar, bar *progressBar) error {
+	if v.err != nil && bar == nil {
+		return v.err
+	}
+	switch {
+	case bar != nil:
+		v.calls = append(v.calls, bar.name)
+	case len(bars) > v.count:
+		v.calls = append(v.calls, "add")
+	case len(bars) < v.count:
+		v.calls = append(v.calls, "remove")
+	default:
+		v.calls = append(v.calls, "pulsate")
+	}
+	v.count = len(bars)
+	return nil
+}
+
+func (v *fakeMultiProgress) Close() error          { return nil }
+func (v *fakeMultiProgress) Done() <-chan struct{} { return nil }
+func (v *fakeMultiProgress) Err() error            { return nil }
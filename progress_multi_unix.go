@@ -0,0 +1,186 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ncruces/zenity/internal/zenutil"
+)
+
+func multiProgress(opts options) (multiProgressView, error) {
+	if zenutil.Yad() == "" {
+		return newCompositeProgress(opts)
+	}
+
+	// Only pass the general options that yad understands.
+	args := []string{"--multi-progress"}
+	if opts.title != nil {
+		args = append(args, "--title", *opts.title)
+	}
+	if opts.modal {
+		args = append(args, "--modal")
+	}
+	args = appendWidthHeight(args, opts)
+	args = appendWindowIcon(args, opts)
+	if opts.okLabel != nil {
+		args = append(args, "--button", *opts.okLabel+":0")
+	}
+	if !opts.noCancel {
+		if opts.cancelLabel == nil {
+			opts.cancelLabel = ptr("yad-cancel")
+		}
+		args = append(args, "--button", *opts.cancelLabel+":1")
+	}
+
+	y := &yadProgress{ctx: opts.ctx, args: args, done: make(chan struct{})}
+	if y.ctx == nil {
+		y.ctx = context.Background()
+	}
+	y.mtx.Lock()
+	defer y.mtx.Unlock()
+	if err := y.start(nil); err != nil {
+		return nil, err
+	}
+	return y, nil
+}
+
+// yadProgress shows each bar separately with yad.
+// Since yad does not support adding or removing bars,
+// yad is restarted, and bars restored, whenever bars change.
+type yadProgress struct {
+	ctx    context.Context
+	args   []string
+	mtx    sync.Mutex
+	cmd    *exec.Cmd
+	pipe   io.WriteCloser
+	closed bool
+	done   chan struct{}
+	err    error
+}
+
+func (y *yadProgress) start(bars []*progressBar) error {
+	args := slices.Clip(y.args)
+	for _, b := range bars {
+		kind := "NORM"
+		if b.pulse {
+			kind = "PULSE"
+		}
+		args = append(args, "--bar", b.name+":"+kind)
+	}
+
+	cmd := exec.CommandContext(y.ctx, zenutil.Yad(), args...)
+	pipe, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		if cerr := y.ctx.Err(); cerr != nil {
+			err = cerr
+		}
+		return err
+	}
+	y.cmd, y.pipe = cmd, pipe
+	go y.wait(cmd)
+
+	for i, b := range bars {
+		y.send(i, b)
+	}
+	return nil
+}
+
+func (y *yadProgress) send(i int, bar *progressBar) {
+	n := strconv.Itoa(i + 1)
+	if bar.text != "" {
+		io.WriteString(y.pipe, n+":#"+bar.text+"\n")
+	}
+	io.WriteString(y.pipe, n+":"+strconv.FormatFloat(bar.percent(), 'f', -1, 64)+"\n")
+}
+
+func (y *yadProgress) wait(cmd *exec.Cmd) {
+	err := cmd.Wait()
+
+	y.mtx.Lock()
+	defer y.mtx.Unlock()
+	if cmd != y.cmd {
+		return // restarted
+	}
+	if cerr := y.ctx.Err(); cerr != nil {
+		err = cerr
+	}
+	if eerr, ok := err.(*exec.ExitError); ok {
+		switch {
+		case y.closed:
+			err = nil
+		case eerr.ExitCode() == 1 || eerr.ExitCode() == 252:
+			err = ErrCanceled
+		default:
+			err = fmt.Errorf("%w: %s", eerr, eerr.Stderr)
+		}
+	}
+	y.err = err
+	close(y.done)
+}
+
+func (y *yadProgress) update(bars []*progressBar, bar *progressBar) error {
+	y.mtx.Lock()
+	defer y.mtx.Unlock()
+	select {
+	default:
+	case <-y.done:
+		return y.err
+	}
+
+	if bar != nil {
+		if i := slices.Index(bars, bar); i >= 0 {
+			y.send(i, bar)
+		}
+		return nil
+	}
+	for _, b := range bars {
+		if strings.Contains(b.name, ":") {
+			return fmt.Errorf("%w: bar name with a colon: %q", ErrUnsupported, b.name)
+		}
+	}
+
+	old := y.cmd
+	y.cmd = nil
+	old.Process.Kill()
+	if err := y.start(bars); err != nil {
+		y.err = err
+		close(y.done)
+		return err
+	}
+	return nil
+}
+
+func (y *yadProgress) Close() error {
+	y.mtx.Lock()
+	y.closed = true
+	if y.cmd != nil {
+		y.cmd.Process.Kill()
+	}
+	y.mtx.Unlock()
+	<-y.done
+	return y.err
+}
+
+func (y *yadProgress) Done() <-chan struct{} {
+	return y.done
+}
+
+func (y *yadProgress) Err() error {
+	select {
+	default:
+		return nil
+	case <-y.done:
+		return y.err
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_yadProgress_colon(t *testing.T) {
+	t.Parallel()
+	y := &yadProgress{done: make(chan struct{})}
+	err := y.update([]*progressBar{{name: "a:b"}}, nil)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("yadProgress.update() = %v; want %v", err, ErrUnsupported)
+	}
+}
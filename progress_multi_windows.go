@@ -0,0 +1,5 @@
+package zenity
+
+func multiProgress(opts options) (multiProgressView, error) {
+	return newCompositeProgress(opts)
+}
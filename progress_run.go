@@ -0,0 +1,159 @@
+package zenity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ProgressStep is a named step of a task run by RunWithProgress.
+type ProgressStep struct {
+	Name   string // dialog text shown while the step runs
+	Weight int    // how much of the task the step accounts for (default 1)
+
+	// Run performs the step, reporting progress to p, which has a MaxValue of 100.
+	// The context is canceled if the dialog is closed, or another step fails.
+	Run func(ctx context.Context, p ProgressTracker) error
+}
+
+// RunWithProgress runs steps while displaying the progress indication dialog.
+//
+// Steps are run sequentially, unless the Concurrency option is used.
+// If the dialog is closed, or a step fails, the context passed to steps is canceled,
+// and no further steps are started.
+//
+// If any step fails, the returned error combines the errors of all failed steps,
+// and is displayed with the error dialog.
+// If the user closes the dialog, the returned error also wraps ErrCanceled.
+// If all steps succeed, the dialog is completed, and then closed.
+//
+// The maximum value of the dialog is 100 times the sum of the step weights,
+// so MaxValue is ignored.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// Icon, WindowIcon, Attach, Modal, Pulsate, NoCancel, TimeRemaining, AutoClose,
+// Concurrency.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
+func RunWithProgress(ctx context.Context, steps []ProgressStep, options ...Option) error {
+	opts := applyOptions(options)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	opts.ctx = ctx
+
+	if opts.maxValue >= 0 {
+		opts.maxValue = 0
+		for _, s := range steps {
+			opts.maxValue += 100 * s.weight()
+		}
+	}
+
+	dlg, err := newProgress(opts)
+	if err != nil {
+		return err
+	}
+
+	err = runWithProgress(ctx, dlg, steps, opts.concurrency)
+	if err != nil && ctx.Err() == nil &&
+		!errors.Is(err, ErrCanceled) && !errors.Is(err, ErrExtraButton) {
+		message(errorKind, err.Error(), generalOptions(opts))
+	}
+	return err
+}
+
+// Concurrency returns an Option to run up to n steps concurrently.
+func Concurrency(n int) Option {
+	return funcOption(func(o *options) { o.concurrency = n })
+}
+
+func (s ProgressStep) weight() int {
+	if s.Weight <= 0 {
+		return 1
+	}
+	return s.Weight
+}
+
+func runWithProgress(parent context.Context, dlg ProgressMonitor, steps []ProgressStep, concurrency int) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var closed atomic.Bool
+	finished := make(chan struct{})
+	go func() {
+		select {
+		case <-dlg.Done():
+			closed.Store(true)
+			cancel()
+		case <-finished:
+		}
+	}()
+
+	var (
+		wg   sync.WaitGroup
+		mtx  sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, max(concurrency, 1))
+	for _, step := range steps {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		p := dlg.Sub(100*step.weight(), 100)
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem }()
+			defer wg.Done()
+
+			dlg.Text(step.Name)
+			err := step.Run(ctx, p)
+			if err == nil {
+				p.Value(100)
+				return
+			}
+			// Drop errors caused by canceling the step.
+			if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+				return
+			}
+			mtx.Lock()
+			errs = append(errs, fmt.Errorf("%s: %w", step.Name, err))
+			mtx.Unlock()
+			cancel()
+		}()
+	}
+	wg.Wait()
+	close(finished)
+
+	var err error
+	if closed.Load() || len(errs) > 0 || ctx.Err() != nil {
+		err = dlg.Close()
+	} else if err = dlg.Complete(); err == nil {
+		// Unless AutoClose closed it, close the completed dialog.
+		select {
+		case <-dlg.Done():
+			err = dlg.Err()
+		default:
+			err = dlg.Close()
+		}
+	}
+	if cerr := parent.Err(); cerr != nil {
+		return cerr
+	}
+	if closed.Load() {
+		if err == nil {
+			err = ErrCanceled
+		}
+		return errors.Join(append([]error{err}, errs...)...)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
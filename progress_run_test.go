@@ -0,0 +1,143 @@
+package zenity
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_runWithProgress(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(300)
+
+	var order []string
+	step := func(name string) ProgressStep {
+		return ProgressStep{Name: name, Run: func(ctx context.Context, p ProgressTracker) error {
+			order = append(order, name)
+			return p.Value(50)
+		}}
+	}
+
+	err := runWithProgress(context.Background(), newProgressTracker(dlg),
+		[]ProgressStep{step("one"), step("two"), step("three")}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"one", "two", "three"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("runWithProgress() order = %v; want %v", order, want)
+	}
+	// Completing the dialog sends the maximum value again.
+	if want := []int{50, 100, 150, 200, 250, 300, 300}; !reflect.DeepEqual(dlg.values, want) {
+		t.Errorf("runWithProgress() values = %v; want %v", dlg.values, want)
+	}
+	if dlg.text != "three" {
+		t.Errorf("runWithProgress() text = %q; want %q", dlg.text, "three")
+	}
+}
+
+func Test_runWithProgress_autoClose(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(100)
+	tracker := newProgressTracker(dlg)
+	tracker.events = make(chan ProgressEvent, 1)
+	tracker.autoClose = true
+	go tracker.watch()
+
+	steps := []ProgressStep{{Name: "one", Run: func(context.Context, ProgressTracker) error { return nil }}}
+	if err := runWithProgress(context.Background(), tracker, steps, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-tracker.Events(); got != ProgressAutoClosed {
+		t.Errorf("Events() = %v; want %v", got, ProgressAutoClosed)
+	}
+}
+
+func Test_runWithProgress_error(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(300)
+	sentinel := errors.New("sentinel")
+
+	var ran atomic.Int32
+	steps := []ProgressStep{
+		{Name: "one", Run: func(ctx context.Context, p ProgressTracker) error {
+			ran.Add(1)
+			return nil
+		}},
+		{Name: "two", Run: func(ctx context.Context, p ProgressTracker) error {
+			ran.Add(1)
+			return sentinel
+		}},
+		{Name: "three", Run: func(ctx context.Context, p ProgressTracker) error {
+			ran.Add(1)
+			return nil
+		}},
+	}
+
+	err := runWithProgress(context.Background(), newProgressTracker(dlg), steps, 0)
+	if !errors.Is(err, sentinel) || errors.Is(err, ErrCanceled) {
+		t.Errorf("runWithProgress() = %v; want %v", err, sentinel)
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "two: ") {
+		t.Errorf("runWithProgress() = %v; want step name", err)
+	}
+	if got := ran.Load(); got != 2 {
+		t.Errorf("runWithProgress() ran %d steps; want 2", got)
+	}
+}
+
+func Test_runWithProgress_canceled(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(100)
+
+	steps := []ProgressStep{
+		{Name: "wait", Run: func(ctx context.Context, p ProgressTracker) error {
+			dlg.Close()
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		{Name: "never", Run: func(ctx context.Context, p ProgressTracker) error {
+			t.Error("step should not run")
+			return nil
+		}},
+	}
+
+	err := runWithProgress(context.Background(), newProgressTracker(dlg), steps, 0)
+	if !errors.Is(err, ErrCanceled) || errors.Is(err, context.Canceled) {
+		t.Errorf("runWithProgress() = %v; want %v", err, ErrCanceled)
+	}
+}
+
+func Test_runWithProgress_concurrency(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(1000)
+
+	var running, peak atomic.Int32
+	steps := make([]ProgressStep, 10)
+	for i := range steps {
+		steps[i].Run = func(ctx context.Context, p ProgressTracker) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := peak.Load()
+				if n <= m || peak.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			return nil
+		}
+	}
+
+	err := runWithProgress(context.Background(), newProgressTracker(dlg), steps, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := peak.Load(); got > 3 {
+		t.Errorf("runWithProgress() ran %d steps concurrently; want at most 3", got)
+	}
+	if got := dlg.values[len(dlg.values)-1]; got != 1000 {
+		t.Errorf("runWithProgress() value = %d; want 1000", got)
+	}
+}
@@ -3,6 +3,7 @@ package zenity_test
 import (
 	"context"
 	"errors"
+	"os/exec"
 	"testing"
 	"time"
 
@@ -66,6 +67,76 @@ func ExampleProgress_pulsate() {
 	time.Sleep(time.Second)
 }
 
+func ExampleProgressMonitor_Sub() {
+	dlg, err := zenity.Progress(
+		zenity.Title("Install Package"))
+	if err != nil {
+		return
+	}
+	defer dlg.Close()
+
+	mon := dlg.(zenity.ProgressMonitor)
+	download := mon.Sub(80, 4096)
+	unpack := mon.Sub(20, 3)
+
+	dlg.Text("Downloading package...")
+	for i := 0; i <= 4096; i += 1024 {
+		download.Value(i)
+		time.Sleep(time.Second / 4)
+	}
+
+	dlg.Text("Unpacking package...")
+	for i := 0; i <= 3; i++ {
+		unpack.Value(i)
+		time.Sleep(time.Second / 4)
+	}
+
+	dlg.Complete()
+	time.Sleep(time.Second)
+}
+
+func ExampleRunWithProgress() {
+	sleep := func(ctx context.Context, p zenity.ProgressTracker) error {
+		for i := 0; i <= 100; i += 25 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second / 4):
+			}
+			p.Value(i)
+		}
+		return nil
+	}
+
+	zenity.RunWithProgress(context.Background(),
+		[]zenity.ProgressStep{
+			{Name: "Scanning mail logs...", Run: sleep},
+			{Name: "Updating mail logs...", Run: sleep},
+			{Name: "Resetting cron jobs...", Run: sleep},
+		},
+		zenity.Title("Update System Logs"))
+}
+
+func ExampleMultiProgress() {
+	dlg, err := zenity.MultiProgress(
+		zenity.Title("Download Files"))
+	if err != nil {
+		return
+	}
+	defer dlg.Close()
+
+	one, _ := dlg.Add("one.zip", 100)
+	two, _ := dlg.Add("two.zip", 100)
+	for i := 0; i <= 100; i += 25 {
+		one.Value(i)
+		two.Value(i / 2)
+		time.Sleep(time.Second / 2)
+	}
+	one.Remove()
+	two.Value(100)
+	time.Sleep(time.Second)
+}
+
 func TestProgress_cancel(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -100,6 +171,34 @@ func TestProgress_cancelAfter(t *testing.T) {
 	}
 }
 
+func TestProgressCmd_cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := zenity.ProgressCmd(ctx, exec.Command("go", "version"), nil)
+	if skip, err := skip(err); skip {
+		t.Skip("skipping:", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Error("was not canceled:", err)
+	}
+}
+
+func TestMultiProgress_cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := zenity.MultiProgress(zenity.Context(ctx))
+	if skip, err := skip(err); skip {
+		t.Skip("skipping:", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Error("was not canceled:", err)
+	}
+}
+
 func TestProgress_examples(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -107,4 +206,5 @@ func TestProgress_examples(t *testing.T) {
 
 	ExampleProgress()
 	ExampleProgress_pulsate()
+	ExampleProgressMonitor_Sub()
 }
@@ -0,0 +1,205 @@
+package zenity
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func Test_progressTracker(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(100)
+	root := newProgressTracker(dlg)
+
+	download := root.Sub(50, 1000)
+	verify := root.Sub(10, 1)
+	unpack := root.Sub(40, 4)
+
+	download.Value(500)
+	download.Value(1000)
+	verify.Value(1)
+	unpack.Value(1)
+	unpack.Value(1) // unchanged, not sent
+	unpack.Sub(1, 2).Value(1)
+	unpack.Value(5) // clamped to MaxValue
+
+	want := []int{25, 50, 60, 70, 75, 100}
+	if got := dlg.values; !reflect.DeepEqual(got, want) {
+		t.Errorf("progressTracker.Value() = %v; want %v", got, want)
+	}
+	if got := unpack.MaxValue(); got != 4 {
+		t.Errorf("progressTracker.MaxValue() = %v; want 4", got)
+	}
+}
+
+func Test_progressTracker_concurrent(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(100)
+	root := newProgressTracker(dlg)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		sub := root.Sub(10, 1000)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1001 {
+				sub.Value(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := dlg.values[len(dlg.values)-1]; got != 100 {
+		t.Errorf("progressTracker.Value() = %v; want 100", got)
+	}
+}
+
+func Test_progressTracker_closed(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(100)
+	root := newProgressTracker(dlg)
+
+	sub := root.Sub(100, 10)
+	sub.Value(1)
+	dlg.Close()
+	if err := sub.Value(1); err != ErrCanceled {
+		t.Errorf("progressTracker.Value() = %v; want %v", err, ErrCanceled)
+	}
+}
+
+func Test_progressTracker_closedUnset(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(-1)
+	root := newProgressTracker(dlg)
+
+	dlg.err = ErrCanceled
+	dlg.Close()
+	if err := root.Sub(1, 1).Value(0); err != ErrCanceled {
+		t.Errorf("progressTracker.Value() = %v; want %v", err, ErrCanceled)
+	}
+	if len(dlg.values) != 0 {
+		t.Errorf("progressTracker.Value() sent %v", dlg.values)
+	}
+}
+
+type fakeProgress struct {
+	mtx    sync.Mutex
+	once   sync.Once
+	max    int
+	text   string
+	values []int
+	done   chan struct{}
+	err    error
+}
+
+func newFakeProgress(max int) *fakeProgress {
+	return &fakeProgress{max: max, done: make(chan struct{})}
+}
+
+func (d *fakeProgress) Text(text string) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	select {
+	default:
+		d.text = text
+		return nil
+	case <-d.done:
+		return ErrCanceled
+	}
+}
+
+func (d *fakeProgress) Value(value int) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	select {
+	default:
+		d.values = append(d.values, value)
+		return nil
+	case <-d.done:
+		return ErrCanceled
+	}
+}
+
+func (d *fakeProgress) MaxValue() int         { return d.max }
+func (d *fakeProgress) Complete() error       { return d.Value(d.max) }
+func (d *fakeProgress) Close() error          { d.once.Do(func() { close(d.done) }); return nil }
+func (d *fakeProgress) Done() <-chan struct{} { return d.done }
+
+func (d *fakeProgress) Err() error {
+	select {
+	default:
+		return nil
+	case <-d.done:
+		return d.err
+	}
+}
+
+func Test_progressTracker_events(t *testing.T) {
+	t.Parallel()
+	sentinel := errors.New("sentinel")
+	tests := []struct {
+		name     string
+		err      error
+		auto     bool
+		complete bool
+		want     ProgressEvent
+	}{
+		{name: "Closed", want: ProgressClosed},
+		{name: "AutoClosed", auto: true, want: ProgressAutoClosed},
+		{name: "Completed", auto: true, complete: true, want: ProgressAutoClosed},
+		{name: "Canceled", err: ErrCanceled, want: ProgressCanceled},
+		{name: "ExtraButton", err: ErrExtraButton, want: ProgressExtraButton},
+		{name: "Expired", err: context.DeadlineExceeded, want: ProgressExpired},
+		{name: "Failed", err: sentinel, want: ProgressFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dlg := newFakeProgress(100)
+			tracker := newProgressTracker(dlg)
+			tracker.events = make(chan ProgressEvent, 1)
+			tracker.autoClose = tt.auto
+			go tracker.watch()
+
+			if tt.complete {
+				tracker.Complete()
+			} else {
+				tracker.Value(100)
+			}
+			dlg.err = tt.err
+			dlg.Close()
+
+			if got := <-tracker.Events(); got != tt.want {
+				t.Errorf("Events() = %v; want %v", got, tt.want)
+			}
+			if _, ok := <-tracker.Events(); ok {
+				t.Error("Events() was not closed")
+			}
+			if got := tracker.Err(); got != tt.err {
+				t.Errorf("Err() = %v; want %v", got, tt.err)
+			}
+		})
+	}
+}
+
+func Test_progressTracker_eventsFull(t *testing.T) {
+	t.Parallel()
+	dlg := newFakeProgress(100)
+	tracker := newProgressTracker(dlg)
+	tracker.events = make(chan ProgressEvent, 1)
+	tracker.events <- ProgressExtraButton
+	go tracker.watch()
+
+	dlg.err = ErrCanceled
+	dlg.Close()
+
+	var got []ProgressEvent
+	for e := range tracker.Events() {
+		got = append(got, e)
+	}
+	if len(got) == 0 || got[len(got)-1] != ProgressCanceled {
+		t.Errorf("Events() = %v; want final %v", got, ProgressCanceled)
+	}
+}
@@ -2,9 +2,17 @@
 
 package zenity
 
-import "github.com/ncruces/zenity/internal/zenutil"
+import (
+	"fmt"
+
+	"github.com/ncruces/zenity/internal/zenutil"
+)
+
+func progress(opts options, extra func()) (progressBackend, error) {
+	if opts.keepOpen {
+		return nil, fmt.Errorf("%w: keep open", ErrUnsupported)
+	}
 
-func progress(opts options) (ProgressDialog, error) {
 	args := []string{"--progress"}
 	args = appendGeneral(args, opts)
 	args = appendButtons(args, opts)
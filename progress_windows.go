@@ -9,7 +9,7 @@ import (
 	"github.com/ncruces/zenity/internal/win"
 )
 
-func progress(opts options) (ProgressDialog, error) {
+func progress(opts options, extra func()) (progressBackend, error) {
 	if opts.title == nil {
 		opts.title = ptr("")
 	}
@@ -32,6 +32,7 @@ func progress(opts options) (ProgressDialog, error) {
 		done:  make(chan struct{}),
 		max:   opts.maxValue,
 		close: opts.autoClose,
+		extra: extra,
 	}
 	dlg.init.Add(1)
 
@@ -50,6 +51,7 @@ type progressDialog struct {
 	err   error
 	max   int
 	close bool
+	extra func()
 
 	wnd       win.HWND
 	textCtl   win.HWND
@@ -94,6 +96,15 @@ func (d *progressDialog) Done() <-chan struct{} {
 	return d.done
 }
 
+func (d *progressDialog) Err() error {
+	select {
+	default:
+		return nil
+	case <-d.done:
+		return d.err
+	}
+}
+
 func (d *progressDialog) Complete() error {
 	select {
 	default:
@@ -109,7 +120,8 @@ func (d *progressDialog) Complete() error {
 }
 
 func (d *progressDialog) Close() error {
-	win.SendMessage(d.wnd, win.WM_SYSCOMMAND, win.SC_CLOSE, 0)
+	// Close as if OK was pressed, so that closing the window is still canceling.
+	win.SendMessage(d.wnd, win.WM_COMMAND, win.IDOK, 0)
 	<-d.done
 	if d.err == ErrCanceled {
 		return nil
@@ -269,6 +281,10 @@ func progressProc(wnd win.HWND, msg uint32, wparam uintptr, lparam *unsafe.Point
 		case win.IDCANCEL:
 			dlg.err = ErrCanceled
 		case win.IDNO:
+			if dlg.extra != nil {
+				dlg.extra()
+				return 0
+			}
 			dlg.err = ErrExtraButton
 		}
 		win.DestroyWindow(wnd)
@@ -0,0 +1,250 @@
+package zenity
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PromptInt displays the text entry dialog, and returns an integer.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, EntryText, Validate, History,
+// PromptMin, PromptMax, PromptDefault.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
+func PromptInt(text string, options ...Option) (int, error) {
+	return promptOrdered(text, applyOptions(options), "an integer", func(s string) (int, error) {
+		return strconv.Atoi(s)
+	}, strconv.Itoa)
+}
+
+// PromptFloat displays the text entry dialog, and returns a finite number.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, EntryText, Validate, History,
+// PromptMin, PromptMax, PromptDefault.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
+func PromptFloat(text string, options ...Option) (float64, error) {
+	return promptOrdered(text, applyOptions(options), "a number", parseFinite, func(f float64) string {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	})
+}
+
+// PromptDuration displays the text entry dialog, and returns a duration,
+// like 1h30m, as accepted by time.ParseDuration.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, EntryText, Validate, History,
+// PromptMin, PromptMax, PromptDefault.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
+func PromptDuration(text string, options ...Option) (time.Duration, error) {
+	return promptOrdered(text, applyOptions(options), "a duration", time.ParseDuration, time.Duration.String)
+}
+
+// PromptURL displays the text entry dialog, and returns an absolute URL.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, EntryText, Validate, History, PromptDefault.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
+func PromptURL(text string, options ...Option) (*url.URL, error) {
+	return prompt(text, applyOptions(options), "a URL", func(s string) (*url.URL, error) {
+		if err := ValidateURL(s); err != nil {
+			return nil, err
+		}
+		return url.Parse(s)
+	}, (*url.URL).String, nil)
+}
+
+// PromptIP displays the text entry dialog, and returns an IP address.
+//
+// Valid options: Title, Width, Height, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, EntryText, Validate, History, PromptDefault.
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
+func PromptIP(text string, options ...Option) (netip.Addr, error) {
+	return prompt(text, applyOptions(options), "an IP address", netip.ParseAddr, netip.Addr.String, nil)
+}
+
+// Parse a finite number: NaN would pass any bounds check.
+func parseFinite(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err == nil && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		err = strconv.ErrSyntax
+	}
+	return f, err
+}
+
+// PromptMin returns an Option to set the minimum value of a prompt.
+// Numbers are converted to the type of the prompt, if they can be exactly;
+// durations must be a time.Duration.
+func PromptMin[T cmp.Ordered](min T) Option {
+	return funcOption(func(o *options) { o.promptMin = min })
+}
+
+// PromptMax returns an Option to set the maximum value of a prompt.
+// Numbers are converted to the type of the prompt, if they can be exactly;
+// durations must be a time.Duration.
+func PromptMax[T cmp.Ordered](max T) Option {
+	return funcOption(func(o *options) { o.promptMax = max })
+}
+
+// PromptDefault returns an Option to set the default value of a prompt.
+// The default value is the initial entry text,
+// and is returned if the entry is left empty.
+func PromptDefault[T any](value T) Option {
+	return funcOption(func(o *options) { o.promptDefault = value })
+}
+
+func promptOrdered[T cmp.Ordered](text string, opts options, kind string,
+	parse func(string) (T, error), format func(T) string) (T, error) {
+	check, err := promptBounds(opts, format)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return prompt(text, opts, kind, parse, format, check)
+}
+
+// Returns a function that checks the bounds set by PromptMin and PromptMax.
+func promptBounds[T cmp.Ordered](opts options, format func(T) string) (func(T) error, error) {
+	min, hasMin, err := optionValue[T]("PromptMin", opts.promptMin)
+	if err != nil {
+		return nil, err
+	}
+	max, hasMax, err := optionValue[T]("PromptMax", opts.promptMax)
+	if err != nil {
+		return nil, err
+	}
+	return func(v T) error {
+		if hasMin && v < min {
+			return fmt.Errorf("value must be at least %s", format(min))
+		}
+		if hasMax && v > max {
+			return fmt.Errorf("value must be at most %s", format(max))
+		}
+		return nil
+	}, nil
+}
+
+func prompt[T any](text string, opts options, kind string,
+	parse func(string) (T, error), format func(T) string,
+	check func(T) error) (T, error) {
+	var zero T
+	value, err := promptParser(opts, kind, parse, check)
+	if err != nil {
+		return zero, err
+	}
+	def, hasDef, _ := optionValue[T]("PromptDefault", opts.promptDefault)
+	if hasDef && opts.entryText == "" {
+		opts.entryText = format(def)
+	}
+
+	opts.validate = func(s string) error {
+		_, err := value(s)
+		return err
+	}
+	// If the history can't be saved, the entry comes with the error.
+	str, err := validEntry(text, opts)
+	if err != nil && str == "" {
+		return zero, err
+	}
+	v, verr := value(str)
+	if verr != nil {
+		return zero, verr
+	}
+	return v, err
+}
+
+// Returns a function that validates and parses the text entered in a prompt.
+func promptParser[T any](opts options, kind string,
+	parse func(string) (T, error), check func(T) error) (func(string) (T, error), error) {
+	def, hasDef, err := optionValue[T]("PromptDefault", opts.promptDefault)
+	if err != nil {
+		return nil, err
+	}
+	validate := opts.validate
+	return func(s string) (T, error) {
+		var zero T
+		if validate != nil {
+			if err := validate(s); err != nil {
+				return zero, err
+			}
+		}
+		s = strings.TrimSpace(s)
+		if s == "" && hasDef {
+			return def, nil
+		}
+		v, err := parse(s)
+		if err != nil {
+			return zero, errors.New("value must be " + kind)
+		}
+		if check != nil {
+			if err := check(v); err != nil {
+				return zero, err
+			}
+		}
+		return v, nil
+	}, nil
+}
+
+// Returns the value of a prompt option, if it is set.
+// Values that can't be converted to T are an error, rather than ignored.
+func optionValue[T any](name string, v any) (T, bool, error) {
+	var zero T
+	if v == nil {
+		return zero, false, nil
+	}
+	if t, ok := convertValue[T](v); ok {
+		return t, true, nil
+	}
+	return zero, false, fmt.Errorf("%w: %s(%v) for a %T prompt", ErrUnsupported, name, v, zero)
+}
+
+// Convert an option value to T, so that PromptMin(0) works for any number.
+// Only exact conversions between predeclared types are allowed,
+// so PromptMin(1.5) does not become 1, nor PromptMax(10) 10ns.
+func convertValue[T any](v any) (T, bool) {
+	if t, ok := v.(T); ok {
+		return t, true
+	}
+	var zero T
+	val := reflect.ValueOf(v)
+	typ := reflect.TypeFor[T]()
+	if !val.IsValid() || !isPredeclared(val.Type()) || !isPredeclared(typ) ||
+		val.Kind() == reflect.String || !val.CanConvert(typ) {
+		return zero, false
+	}
+	res := val.Convert(typ)
+	if !res.CanConvert(val.Type()) || !res.Convert(val.Type()).Equal(val) ||
+		isNegative(res) != isNegative(val) {
+		return zero, false
+	}
+	return res.Interface().(T), true
+}
+
+// Reports whether val is a negative number.
+func isNegative(val reflect.Value) bool {
+	switch {
+	case val.CanInt():
+		return val.Int() < 0
+	case val.CanFloat():
+		return val.Float() < 0
+	}
+	return false
+}
+
+// Reports whether typ is a predeclared type, like int or float64.
+func isPredeclared(typ reflect.Type) bool {
+	return typ.PkgPath() == "" && typ.Name() == typ.Kind().String()
+}
@@ -0,0 +1,121 @@
+package zenity
+
+import (
+	"errors"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_promptParser(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		opts  []Option
+		text  string
+		want  int
+		valid bool
+	}{
+		{nil, "1", 1, true},
+		{nil, " 2 ", 2, true},
+		{nil, "", 0, false},
+		{nil, "x", 0, false},
+		{[]Option{PromptDefault(5)}, "", 5, true},
+		{[]Option{PromptDefault(5)}, "6", 6, true},
+		{[]Option{PromptMin(1), PromptMax(10)}, "0", 0, false},
+		{[]Option{PromptMin(1), PromptMax(10)}, "1", 1, true},
+		{[]Option{PromptMin(1), PromptMax(10)}, "10", 10, true},
+		{[]Option{PromptMin(1), PromptMax(10)}, "11", 0, false},
+		{[]Option{PromptMin(1.0)}, "0", 0, false},
+		{[]Option{Validate(ValidateLength(2, 0))}, "3", 0, false},
+		{[]Option{Validate(ValidateLength(2, 0))}, "33", 33, true},
+	}
+	for _, tt := range tests {
+		opts := applyOptions(tt.opts)
+		check, err := promptBounds(opts, strconv.Itoa)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parse, err := promptParser(opts, "an integer", strconv.Atoi, check)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parse(tt.text)
+		if (err == nil) != tt.valid || got != tt.want {
+			t.Errorf("promptParser(%q) = %v, %v; want %v, valid %v", tt.text, got, err, tt.want, tt.valid)
+		}
+	}
+}
+
+func Test_promptParser_options(t *testing.T) {
+	t.Parallel()
+	format := func(d time.Duration) string { return d.String() }
+	tests := []struct {
+		opts  []Option
+		valid bool
+	}{
+		{[]Option{PromptMin(time.Second), PromptMax(time.Minute)}, true},
+		{[]Option{PromptMax(10)}, false},
+		{[]Option{PromptMin(1.5)}, false},
+		{[]Option{PromptDefault("1s")}, false},
+	}
+	for _, tt := range tests {
+		opts := applyOptions(tt.opts)
+		_, berr := promptBounds(opts, format)
+		_, perr := promptParser(opts, "a duration", time.ParseDuration, nil)
+		if err := errors.Join(berr, perr); (err == nil) != tt.valid {
+			t.Errorf("promptParser(%v) = %v; want valid %v", opts, err, tt.valid)
+		} else if err != nil && !errors.Is(err, ErrUnsupported) {
+			t.Errorf("promptParser(%v) = %v; want %v", opts, err, ErrUnsupported)
+		}
+	}
+}
+
+func Test_parseFinite(t *testing.T) {
+	t.Parallel()
+	for _, text := range []string{"NaN", "nan", "Inf", "-Infinity", "+inf", "1e400"} {
+		if f, err := parseFinite(text); err == nil {
+			t.Errorf("parseFinite(%q) = %v", text, f)
+		}
+	}
+	if f, err := parseFinite("-1.5e3"); f != -1500 || err != nil {
+		t.Errorf("parseFinite(%q) = %v, %v", "-1.5e3", f, err)
+	}
+}
+
+func Test_convertValue(t *testing.T) {
+	t.Parallel()
+	if got, ok := convertValue[float64](1); !ok || got != 1 {
+		t.Errorf("convertValue[float64](1) = %v, %v", got, ok)
+	}
+	if got, ok := convertValue[time.Duration](time.Second); !ok || got != time.Second {
+		t.Errorf("convertValue[time.Duration](time.Second) = %v, %v", got, ok)
+	}
+	if got, ok := convertValue[netip.Addr](netip.IPv6Loopback()); !ok || got != netip.IPv6Loopback() {
+		t.Errorf("convertValue[netip.Addr](::1) = %v, %v", got, ok)
+	}
+	if _, ok := convertValue[int](nil); ok {
+		t.Error("convertValue[int](nil) succeeded")
+	}
+	if _, ok := convertValue[int]("1"); ok {
+		t.Error(`convertValue[int]("1") succeeded`)
+	}
+	if got, ok := convertValue[int](2.0); !ok || got != 2 {
+		t.Errorf("convertValue[int](2.0) = %v, %v", got, ok)
+	}
+	if _, ok := convertValue[int](1.5); ok {
+		t.Error("convertValue[int](1.5) succeeded")
+	}
+	if _, ok := convertValue[uint](-1); ok {
+		t.Error("convertValue[uint](-1) succeeded")
+	}
+	if _, ok := convertValue[int8](uint8(200)); ok {
+		t.Error("convertValue[int8](200) succeeded")
+	}
+	if _, ok := convertValue[time.Duration](10); ok {
+		t.Error("convertValue[time.Duration](10) succeeded")
+	}
+	if _, ok := convertValue[int](time.Second); ok {
+		t.Error("convertValue[int](time.Second) succeeded")
+	}
+}
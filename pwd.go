@@ -1,16 +1,262 @@
 package zenity
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/ncruces/zenity/internal/zenutil"
+)
+
 // Password displays the password dialog.
 //
 // Valid options: Title, OKLabel, CancelLabel, ExtraButton,
-// WindowIcon, Attach, Modal, Username.
+// WindowIcon, Attach, Modal, Username, EntryText, Validate,
+// ConfirmPassword, PasswordPolicy, RememberCredentials, Credentials, RememberLabels.
 //
-// May return: ErrCanceled, ErrExtraButton.
+// With Username, EntryText sets the initial username (Windows and macOS only).
+//
+// May return: ErrCanceled, ErrExtraButton, ErrUnsupported.
 func Password(options ...Option) (usr string, pwd string, err error) {
-	return password(applyOptions(options))
+	opts := applyOptions(options)
+	if opts.rememberCredentials != nil {
+		return rememberedPassword(opts)
+	}
+	return validPassword(opts)
+}
+
+// Show the password dialog until the password is valid, and confirmed.
+func validPassword(opts options) (string, string, error) {
+	for {
+		usr, pwd, err := password(opts)
+		if err != nil {
+			return usr, pwd, err
+		}
+		if verr := checkPassword(opts, pwd); verr != nil {
+			if err := showPasswordError(opts, verr); err != nil {
+				return "", "", err
+			}
+			continue
+		}
+		if opts.confirmPassword {
+			_, again, err := password(confirmOptions(opts))
+			if err != nil {
+				return "", "", err
+			}
+			if again != pwd {
+				if err := showPasswordError(opts, errors.New("passwords do not match")); err != nil {
+					return "", "", err
+				}
+				continue
+			}
+		}
+		return usr, pwd, nil
+	}
+}
+
+// PasswordBytes displays the password dialog,
+// and returns the password as a byte slice, which can be wiped after use.
+//
+// The password is read directly from the dialog,
+// it is never converted to a string, nor passed as an argument to a process,
+// and errors do not include the dialog's output.
+// Validate and PasswordPolicy are passed a string that shares memory
+// with the password, and is wiped along with it, so it must not be retained.
+//
+// Valid options: Title, OKLabel, CancelLabel, ExtraButton,
+// WindowIcon, Attach, Modal, Username, Validate,
+// ConfirmPassword, PasswordPolicy.
+//
+// May return: ErrCanceled, ErrExtraButton.
+func PasswordBytes(options ...Option) (usr string, pwd []byte, err error) {
+	opts := applyOptions(options)
+	for {
+		usr, pwd, err := passwordSecret(opts)
+		if err != nil {
+			return usr, pwd, err
+		}
+		if verr := checkPassword(opts, unsafe.String(unsafe.SliceData(pwd), len(pwd))); verr != nil {
+			Wipe(pwd)
+			if err := showPasswordError(opts, verr); err != nil {
+				return "", nil, err
+			}
+			continue
+		}
+		if !opts.confirmPassword {
+			return usr, pwd, nil
+		}
+		_, again, err := passwordSecret(confirmOptions(opts))
+		if err != nil {
+			Wipe(pwd)
+			return "", nil, err
+		}
+		match := bytes.Equal(pwd, again)
+		Wipe(again)
+		if match {
+			return usr, pwd, nil
+		}
+		Wipe(pwd)
+		if err := showPasswordError(opts, errors.New("passwords do not match")); err != nil {
+			return "", nil, err
+		}
+	}
+}
+
+// Wipe overwrites secret with zeros,
+// including any capacity beyond its length.
+func Wipe(secret []byte) {
+	zenutil.Wipe(secret)
 }
 
 // Username returns an Option to display the username.
 func Username() Option {
 	return funcOption(func(o *options) { o.username = true })
 }
+
+// ConfirmPassword returns an Option to ask for the password twice,
+// with the same title.
+// If the passwords do not match, the error is shown,
+// and the password dialog is shown again.
+func ConfirmPassword() Option {
+	return funcOption(func(o *options) { o.confirmPassword = true })
+}
+
+// PasswordPolicy is an Option that sets the requirements
+// a password must meet.
+// If the password does not meet them, the error is shown,
+// and the password dialog is shown again.
+//
+// MinEntropy is checked against a crude estimate: the number of distinct
+// characters, times log2 of the size of the character classes used
+// (lowercase, uppercase, digits, ASCII symbols, and others).
+// It does not detect words, keyboard patterns, or other guessable passwords.
+type PasswordPolicy struct {
+	MinLength  int      // minimum number of characters
+	Lower      bool     // require a lowercase letter
+	Upper      bool     // require an uppercase letter
+	Digit      bool     // require a digit
+	Symbol     bool     // require a symbol, or other character
+	MinEntropy float64  // minimum estimated entropy, in bits
+	DenyList   []string // passwords that are not allowed, case-insensitive
+}
+
+func (p PasswordPolicy) apply(o *options) { o.passwordPolicy = &p }
+
+// Check returns an error if pwd does not meet the policy.
+func (p PasswordPolicy) Check(pwd string) error {
+	if n := utf8.RuneCountInString(pwd); n < p.MinLength {
+		return fmt.Errorf("password must have at least %d characters", p.MinLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range pwd {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	switch {
+	case p.Lower && !lower:
+		return errors.New("password must have a lowercase letter")
+	case p.Upper && !upper:
+		return errors.New("password must have an uppercase letter")
+	case p.Digit && !digit:
+		return errors.New("password must have a digit")
+	case p.Symbol && !symbol:
+		return errors.New("password must have a symbol")
+	}
+
+	for _, deny := range p.DenyList {
+		if strings.EqualFold(pwd, deny) {
+			return errors.New("password is too common")
+		}
+	}
+	if p.MinEntropy > 0 && passwordEntropy(pwd) < p.MinEntropy {
+		return errors.New("password is too weak")
+	}
+	return nil
+}
+
+// Estimate the entropy of a password, in bits,
+// as the number of distinct characters
+// times log2 of the size of the character classes it uses.
+// Repeated characters add no entropy.
+func passwordEntropy(pwd string) float64 {
+	var pool int
+	var lower, upper, digit, ascii, other bool
+	seen := map[rune]bool{}
+	for _, r := range pwd {
+		seen[r] = true
+		switch {
+		case 'a' <= r && r <= 'z':
+			lower = true
+		case 'A' <= r && r <= 'Z':
+			upper = true
+		case '0' <= r && r <= '9':
+			digit = true
+		case r < utf8.RuneSelf:
+			ascii = true
+		default:
+			other = true
+		}
+	}
+	if lower {
+		pool += 26
+	}
+	if upper {
+		pool += 26
+	}
+	if digit {
+		pool += 10
+	}
+	if ascii {
+		pool += 33
+	}
+	if other {
+		pool += 100
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(len(seen)) * math.Log2(float64(pool))
+}
+
+func checkPassword(opts options, pwd string) error {
+	if opts.passwordPolicy != nil {
+		if err := opts.passwordPolicy.Check(pwd); err != nil {
+			return err
+		}
+	}
+	if opts.validate != nil {
+		return opts.validate(pwd)
+	}
+	return nil
+}
+
+// Options for the dialog that confirms a password.
+func confirmOptions(opts options) options {
+	res := generalOptions(opts)
+	res.okLabel = opts.okLabel
+	res.cancelLabel = opts.cancelLabel
+	return res
+}
+
+// The password dialog has no prompt, so show errors in another dialog.
+func showPasswordError(opts options, verr error) error {
+	err := message(errorKind, verr.Error(), generalOptions(opts))
+	if err == ErrCanceled || err == ErrExtraButton {
+		err = nil
+	}
+	return err
+}
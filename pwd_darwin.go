@@ -14,8 +14,37 @@ func password(opts options) (string, string, error) {
 		return "", str, err
 	}
 
-	var data zenutil.Password
+	data, err := passwordData(opts)
+	if err != nil {
+		return "", "", err
+	}
+	out, err := zenutil.Run(opts.ctx, "pwd", data)
+	return pwdResult(zenutil.Separator, opts, out, err)
+}
+
+func passwordSecret(opts options) (string, []byte, error) {
+	if !opts.username {
+		opts.entryText = ""
+		opts.hideText = true
+		data, err := entryData("Password:", opts)
+		if err != nil {
+			return "", nil, err
+		}
+		out, err := zenutil.RunSecret(opts.ctx, "dialog", data)
+		return pwdSecretResult(zenutil.Separator, opts, out, err)
+	}
+
+	data, err := passwordData(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	out, err := zenutil.RunSecret(opts.ctx, "pwd", data)
+	return pwdSecretResult(zenutil.Separator, opts, out, err)
+}
+
+func passwordData(opts options) (data zenutil.Password, err error) {
 	data.Separator = zenutil.Separator
+	data.Username = opts.entryText
 	data.Options.Title = opts.title
 	data.Options.Timeout = zenutil.Timeout
 	if opts.attach != nil {
@@ -28,14 +57,12 @@ func password(opts options) (string, string, error) {
 	case string:
 		_, err := os.Stat(i)
 		if err != nil {
-			return "", "", err
+			return data, err
 		}
 		data.IconPath = i
 	case DialogIcon:
 		data.Options.Icon = i.String()
 	}
 	data.SetButtons(getButtons(true, true, opts))
-
-	out, err := zenutil.Run(opts.ctx, "pwd", data)
-	return pwdResult(zenutil.Separator, opts, out, err)
+	return data, nil
 }
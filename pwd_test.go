@@ -22,6 +22,60 @@ func ExamplePassword_username() {
 		zenity.Username())
 }
 
+func ExamplePasswordBytes() {
+	_, pwd, err := zenity.PasswordBytes(zenity.Title("Type your password"))
+	if err == nil {
+		defer zenity.Wipe(pwd)
+	}
+}
+
+func ExampleRememberCredentials() {
+	zenity.Password(
+		zenity.Title("Sign in to example.com"),
+		zenity.Username(),
+		zenity.RememberCredentials("example.com"))
+}
+
+func ExamplePasswordPolicy() {
+	zenity.Password(
+		zenity.Title("Choose a new password"),
+		zenity.ConfirmPassword(),
+		zenity.PasswordPolicy{MinLength: 12, MinEntropy: 60})
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		policy zenity.PasswordPolicy
+		pwd    string
+		valid  bool
+	}{
+		{zenity.PasswordPolicy{}, "", true},
+		{zenity.PasswordPolicy{MinLength: 4}, "abc", false},
+		{zenity.PasswordPolicy{MinLength: 4}, "αβγδ", true},
+		{zenity.PasswordPolicy{Lower: true}, "ABC", false},
+		{zenity.PasswordPolicy{Lower: true}, "ABc", true},
+		{zenity.PasswordPolicy{Upper: true}, "abc", false},
+		{zenity.PasswordPolicy{Upper: true}, "Abc", true},
+		{zenity.PasswordPolicy{Digit: true}, "abc", false},
+		{zenity.PasswordPolicy{Digit: true}, "abc1", true},
+		{zenity.PasswordPolicy{Symbol: true}, "abc1", false},
+		{zenity.PasswordPolicy{Symbol: true}, "abc!", true},
+		{zenity.PasswordPolicy{DenyList: []string{"password"}}, "Password", false},
+		{zenity.PasswordPolicy{DenyList: []string{"password"}}, "Password1", true},
+		{zenity.PasswordPolicy{MinEntropy: 40}, "aaaaaaaaaaaaaaaa", false},
+		{zenity.PasswordPolicy{MinEntropy: 40}, "abcdefghi", true},
+		{zenity.PasswordPolicy{MinEntropy: 40}, "Tr0ub4dor", true},
+		{zenity.PasswordPolicy{MinEntropy: 60}, "Tr0ub4dor", false},
+	}
+	for _, tt := range tests {
+		err := tt.policy.Check(tt.pwd)
+		if (err == nil) != tt.valid {
+			t.Errorf("%+v.Check(%q) = %v; want valid %v", tt.policy, tt.pwd, err, tt.valid)
+		}
+	}
+}
+
 func TestPassword_timeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -5,13 +5,21 @@ package zenity
 import "github.com/ncruces/zenity/internal/zenutil"
 
 func password(opts options) (string, string, error) {
+	out, err := zenutil.Run(opts.ctx, passwordArgs(opts))
+	return pwdResult("|", opts, out, err)
+}
+
+func passwordSecret(opts options) (string, []byte, error) {
+	out, err := zenutil.RunSecret(opts.ctx, passwordArgs(opts))
+	return pwdSecretResult("|", opts, out, err)
+}
+
+func passwordArgs(opts options) []string {
 	args := []string{"--password"}
 	args = appendGeneral(args, opts)
 	args = appendButtons(args, opts)
 	if opts.username {
 		args = append(args, "--username")
 	}
-
-	out, err := zenutil.Run(opts.ctx, args)
-	return pwdResult("|", opts, out, err)
+	return args
 }
@@ -0,0 +1,47 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPasswordBytes_policy(t *testing.T) {
+	dir := installFakeTool(t, `#!/bin/sh
+dir=$(dirname "$0")
+for arg; do
+	case "$arg" in
+	--password)
+		echo >> "$dir/count"
+		n=$(wc -l < "$dir/count")
+		sed -n "${n}p" "$dir/passwords"
+		exit ;;
+	--error) printf '%s\n' "$*" >> "$dir/errors"; exit ;;
+	esac
+done
+exit 1
+`)
+	err := os.WriteFile(filepath.Join(dir, "passwords"), []byte("short\nlong enough\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, pwd, err := PasswordBytes(PasswordPolicy{MinLength: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(pwd) != "long enough" {
+		t.Errorf("PasswordBytes() = %q; want %q", pwd, "long enough")
+	}
+
+	errors, err := os.ReadFile(filepath.Join(dir, "errors"))
+	if err != nil {
+		t.Fatal("PasswordBytes() did not show an error:", err)
+	}
+	if !strings.Contains(string(errors), "at least 8 characters") {
+		t.Errorf("PasswordBytes() showed %q", errors)
+	}
+}
@@ -15,24 +15,33 @@ func password(opts options) (string, string, error) {
 		return "", str, err
 	}
 
-	if opts.title == nil {
-		opts.title = ptr("")
-	}
-	if opts.okLabel == nil {
-		opts.okLabel = ptr("OK")
-	}
-	if opts.cancelLabel == nil {
-		opts.cancelLabel = ptr("Cancel")
-	}
-
 	dlg := &passwordDialog{}
 	return dlg.setup(opts)
 }
 
+func passwordSecret(opts options) (string, []byte, error) {
+	if !opts.username {
+		opts.entryText = ""
+		opts.hideText = true
+		pwd, err := entrySecret("Password:", opts)
+		return "", pwd, err
+	}
+
+	dlg := &passwordDialog{secret: true}
+	usr, _, err := dlg.setup(opts)
+	if err != nil {
+		Wipe(dlg.bytes)
+		return "", nil, err
+	}
+	return usr, dlg.bytes, nil
+}
+
 type passwordDialog struct {
-	usr string
-	pwd string
-	err error
+	usr    string
+	pwd    string
+	bytes  []byte
+	secret bool
+	err    error
 
 	wnd       win.HWND
 	uTextCtl  win.HWND
@@ -46,6 +55,16 @@ type passwordDialog struct {
 }
 
 func (dlg *passwordDialog) setup(opts options) (string, string, error) {
+	if opts.title == nil {
+		opts.title = ptr("")
+	}
+	if opts.okLabel == nil {
+		opts.okLabel = ptr("OK")
+	}
+	if opts.cancelLabel == nil {
+		opts.cancelLabel = ptr("Cancel")
+	}
+
 	owner, _ := opts.attach.(win.HWND)
 	defer setup(owner)()
 	dlg.font = getFont()
@@ -79,7 +98,7 @@ func (dlg *passwordDialog) setup(opts options) (string, string, error) {
 		12, 10, 241, 16, dlg.wnd, 0, instance, nil)
 
 	dlg.uEditCtl, _ = win.CreateWindowEx(win.WS_EX_CLIENTEDGE,
-		strptr("EDIT"), nil,
+		strptr("EDIT"), strptr(opts.entryText),
 		_WS_ZEN_CONTROL|win.ES_AUTOHSCROLL,
 		12, 30, 241, 24, dlg.wnd, 0, instance, nil)
 
@@ -184,7 +203,11 @@ func passwordProc(wnd win.HWND, msg uint32, wparam uintptr, lparam *unsafe.Point
 			return 1
 		case win.IDOK, win.IDYES:
 			dlg.usr = win.GetWindowText(dlg.uEditCtl)
-			dlg.pwd = win.GetWindowText(dlg.pEditCtl)
+			if dlg.secret {
+				dlg.bytes = win.GetWindowTextBytes(dlg.pEditCtl)
+			} else {
+				dlg.pwd = win.GetWindowText(dlg.pEditCtl)
+			}
 		case win.IDCANCEL:
 			dlg.err = ErrCanceled
 		case win.IDNO:
@@ -0,0 +1,153 @@
+package zenity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math/rand/v2"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// stateDir returns the directory where state is persisted:
+// $XDG_STATE_HOME/zenity, which defaults to ~/.local/state/zenity.
+// On Windows, it defaults to %LocalAppData%\zenity,
+// and on macOS, to ~/Library/Application Support/zenity.
+func stateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "zenity"), nil
+	}
+
+	var dir string
+	var err error
+	switch runtime.GOOS {
+	case "windows":
+		dir, err = os.UserCacheDir()
+	case "darwin":
+		dir, err = os.UserConfigDir()
+	default:
+		dir, err = os.UserHomeDir()
+		dir = filepath.Join(dir, ".local/state")
+	}
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "zenity"), nil
+}
+
+// statePath returns the path of the state file for key, of the given kind.
+func statePath(kind, key string) (string, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, kind, stateName(key)), nil
+}
+
+// Maximum length of a state file name,
+// which leaves room for the temporary files of writeFileAtomic
+// within the usual limit of 255 bytes.
+const maxStateName = 200
+
+// stateName returns the file name for key.
+// Keys are escaped, so any string is a valid key,
+// and long keys are truncated, and suffixed with their hash.
+func stateName(key string) string {
+	name := "_" + url.QueryEscape(key)
+	if len(name) > maxStateName {
+		sum := sha256.Sum256([]byte(key))
+		name = name[:maxStateName-1-2*len(sum)] + "_" + hex.EncodeToString(sum[:])
+	}
+	return name
+}
+
+func readState(kind, key string) ([]byte, error) {
+	path, err := statePath(kind, key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+func writeState(kind, key string, data []byte) error {
+	path, err := statePath(kind, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, bytes.NewReader(data), 0600)
+}
+
+// writeFileAtomic writes the contents of r to a temporary file,
+// in the same directory as path, syncs it, then renames it to path.
+// Concurrent writers never corrupt the file: the last rename wins.
+//
+// New files are created with perm (before umask).
+// If path exists, its permissions are preserved.
+// If path is a symbolic link, its target is replaced.
+func writeFileAtomic(path string, r io.Reader, perm os.FileMode) (err error) {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+	fi, err := os.Stat(path)
+	if err == nil {
+		perm = fi.Mode().Perm()
+	}
+
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	var f *os.File
+	for range 100 {
+		tmp := "." + name + "." + strconv.FormatUint(uint64(rand.Uint32()), 36) + ".tmp"
+		f, err = os.OpenFile(filepath.Join(dir, tmp), os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if !os.IsExist(err) {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = io.Copy(f, r); err != nil {
+		return err
+	}
+	if fi != nil {
+		// Not subject to umask.
+		if err = f.Chmod(perm); err != nil {
+			return err
+		}
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+
+	// Make the rename durable.
+	if runtime.GOOS != "windows" {
+		if d, err := os.Open(dir); err == nil {
+			d.Sync()
+			d.Close()
+		}
+	}
+	return nil
+}
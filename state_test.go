@@ -0,0 +1,195 @@
+package zenity
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_statePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", dir)
+
+	for _, key := range []string{"", ".", "..", "a/b", `c:\d`, "e f"} {
+		path, err := statePath("test", key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := filepath.Dir(path); got != filepath.Join(dir, "zenity", "test") {
+			t.Errorf("statePath(%q) = %q", key, path)
+		}
+	}
+}
+
+func Test_stateName(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("é", 200)
+	name := stateName(long)
+	if len(name) != maxStateName {
+		t.Errorf("stateName() has length %d; want %d", len(name), maxStateName)
+	}
+	if name == stateName(long+"x") {
+		t.Error("stateName() has the same name for different keys")
+	}
+	if got := stateName("a/b"); got != "_a%2Fb" {
+		t.Errorf("stateName() = %q; want %q", got, "_a%2Fb")
+	}
+}
+
+func Test_writeState_longKey(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	key := strings.Repeat("k", 1000)
+	if err := writeState("test", key, []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := readState("test", key); string(data) != "value" {
+		t.Errorf("readState() = %q, %v", data, err)
+	}
+}
+
+func Test_writeState(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	if _, err := readState("test", "key"); !os.IsNotExist(err) {
+		t.Errorf("readState() = %v; want not exist", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Go(func() {
+			if err := writeState("test", "key", fmt.Appendf(nil, "value %d", i)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	data, err := readState("test", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var i int
+	if _, err := fmt.Sscanf(string(data), "value %d", &i); err != nil {
+		t.Errorf("readState() = %q", data)
+	}
+
+	path, _ := statePath("test", "key")
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("writeState() left %d files", len(entries))
+	}
+}
+
+func Test_rememberLocation(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	dir := t.TempDir()
+
+	opts := applyOptions([]Option{RememberLocation("key")})
+	rememberLocation(opts, filepath.Join(dir, "file.txt"))
+
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"", dir + string(filepath.Separator)},
+		{"report.pdf", filepath.Join(dir, "report.pdf")},
+		{filepath.Join(t.TempDir(), "report.pdf"), filepath.Join(dir, "report.pdf")},
+	}
+	for _, tt := range tests {
+		opts.filename = tt.filename
+		recallLocation(&opts)
+		if opts.filename != tt.want {
+			t.Errorf("recallLocation(%q) = %q; want %q", tt.filename, opts.filename, tt.want)
+		}
+	}
+
+	opts = applyOptions([]Option{RememberLocation("other")})
+	recallLocation(&opts)
+	if opts.filename != "" {
+		t.Errorf("recallLocation() = %q; want empty", opts.filename)
+	}
+}
+
+func Test_writeFileAtomic(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+
+	if err := writeFileAtomic(path, strings.NewReader("one"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, strings.NewReader("two"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "two" {
+		t.Errorf("writeFileAtomic() = %q, %v; want %q", data, err, "two")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("writeFileAtomic() left %d files", len(entries))
+	}
+}
+
+func Test_writeFileAtomic_perm(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping: no permissions on Windows.")
+	}
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "script.sh")
+
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0751); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, strings.NewReader("#!/bin/sh\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0751 {
+		t.Errorf("writeFileAtomic() mode = %v, %v; want %v", fi.Mode(), err, os.FileMode(0751))
+	}
+}
+
+func Test_writeFileAtomic_symlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping: symlinks may require privileges on Windows.")
+	}
+	t.Parallel()
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link.txt")
+
+	if err := os.WriteFile(target, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(link, strings.NewReader("new"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Lstat(link); err != nil || fi.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("writeFileAtomic() replaced the link: %v", err)
+	}
+	if data, err := os.ReadFile(target); err != nil || string(data) != "new" {
+		t.Errorf("writeFileAtomic() = %q, %v; want %q", data, err, "new")
+	}
+}
+
+func Test_writeFileAtomic_error(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "missing", "file.txt")
+
+	if err := writeFileAtomic(path, strings.NewReader(""), 0666); err == nil {
+		t.Error("writeFileAtomic() did not fail")
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -107,7 +109,10 @@ func strResult(opts options, out []byte, err error) (string, error) {
 	return string(out), nil
 }
 
-func lstResult(opts options, out []byte, err error) ([]string, error) {
+// Parses a list of values, all of which must be valid.
+// Values that contain the separator are split incorrectly,
+// so invalid values are rejoined with their neighbors to make them valid.
+func lstResult(opts options, valid func(string) bool, out []byte, err error) ([]string, error) {
 	str, err := strResult(opts, out, err)
 	if err != nil {
 		return nil, err
@@ -115,7 +120,87 @@ func lstResult(opts options, out []byte, err error) ([]string, error) {
 	if len(out) == 0 {
 		return []string{}, nil
 	}
-	return strings.Split(str, zenutil.Separator), nil
+	res, ok := splitValid(str, zenutil.Separator, valid)
+	if !ok {
+		return nil, fmt.Errorf("invalid selection: %q", str)
+	}
+	return res, nil
+}
+
+// Split str on sep, into values that are all valid.
+// Prefers the split with the shortest values.
+func splitValid(str, sep string, valid func(string) bool) ([]string, bool) {
+	parts := strings.Split(str, sep)
+	if valid == nil || !slices.ContainsFunc(parts, func(s string) bool { return !valid(s) }) {
+		return parts, true
+	}
+
+	// next[i] is the end of the first value of a valid split of parts[i:],
+	// or zero if there is none.
+	n := len(parts)
+	next := make([]int, n+1)
+	next[n] = n
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j <= n; j++ {
+			if next[j] != 0 && valid(strings.Join(parts[i:j], sep)) {
+				next[i] = j
+				break
+			}
+		}
+	}
+	if next[0] == 0 {
+		return nil, false
+	}
+
+	var res []string
+	for i := 0; i < n; i = next[i] {
+		res = append(res, strings.Join(parts[i:next[i]], sep))
+	}
+	return res, true
+}
+
+// Returns a function that reports whether a value is one of items.
+func validItems(items []string) func(string) bool {
+	set := make(map[string]struct{}, len(items))
+	for _, i := range items {
+		set[i] = struct{}{}
+	}
+	return func(s string) bool {
+		_, ok := set[s]
+		return ok
+	}
+}
+
+// Reports whether a path exists.
+func validPath(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// Like pwdResult, but for a secret password.
+// The output is wiped on error, and stderr is not included in errors.
+func pwdSecretResult(sep string, opts options, out []byte, err error) (string, []byte, error) {
+	pwd := bytes.TrimSuffix(out, []byte{'\n'})
+	if eerr, ok := err.(*exec.ExitError); ok {
+		defer Wipe(out)
+		if eerr.ExitCode() == 1 {
+			if opts.extraButton != nil && *opts.extraButton == string(pwd) {
+				return "", nil, ErrExtraButton
+			}
+			return "", nil, ErrCanceled
+		}
+		return "", nil, eerr
+	}
+	if err != nil {
+		Wipe(out)
+		return "", nil, err
+	}
+	if opts.username {
+		defer Wipe(out)
+		usr, pwd, _ := bytes.Cut(pwd, []byte(sep))
+		return string(usr), bytes.Clone(pwd), nil
+	}
+	return "", pwd, nil
 }
 
 func pwdResult(sep string, opts options, out []byte, err error) (string, string, error) {
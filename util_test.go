@@ -1,10 +1,12 @@
 package zenity
 
 import (
+	"bytes"
 	"errors"
 	"os/exec"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/ncruces/zenity/internal/zenutil"
@@ -182,19 +184,19 @@ func Test_lstResult(t *testing.T) {
 	zenutil.Separator = "|"
 	t.Parallel()
 
-	if out, err := lstResult(options{}, []byte(""), nil); !reflect.DeepEqual(out, []string{}) || err != nil {
+	if out, err := lstResult(options{}, nil, []byte(""), nil); !reflect.DeepEqual(out, []string{}) || err != nil {
 		t.Errorf(`lstResult("", nil) = %v, %v`, out, err)
 	}
-	if out, err := lstResult(options{}, []byte("out"), nil); !reflect.DeepEqual(out, []string{"out"}) || err != nil {
+	if out, err := lstResult(options{}, nil, []byte("out"), nil); !reflect.DeepEqual(out, []string{"out"}) || err != nil {
 		t.Errorf(`lstResult("out", nil) = %v, %v`, out, err)
 	}
-	if out, err := lstResult(options{}, []byte("one|two"), nil); !reflect.DeepEqual(out, []string{"one", "two"}) || err != nil {
+	if out, err := lstResult(options{}, nil, []byte("one|two"), nil); !reflect.DeepEqual(out, []string{"one", "two"}) || err != nil {
 		t.Errorf(`lstResult("one|two", nil) = %v, %v`, out, err)
 	}
-	if out, err := lstResult(options{}, []byte("out"), sentinel); out != nil || err != sentinel {
+	if out, err := lstResult(options{}, nil, []byte("out"), sentinel); out != nil || err != sentinel {
 		t.Errorf(`lstResult("out", error) = %v, %v`, out, err)
 	}
-	if out, err := lstResult(options{}, []byte("out"), cancel); out != nil || err != ErrCanceled {
+	if out, err := lstResult(options{}, nil, []byte("out"), cancel); out != nil || err != ErrCanceled {
 		t.Errorf(`lstResult("out", cancel) = %v, %v`, out, err)
 	}
 }
@@ -225,6 +227,89 @@ func Test_pwdResult(t *testing.T) {
 	}
 }
 
+func Test_lstResult_separator(t *testing.T) {
+	zenutil.Separator = "|"
+	t.Parallel()
+
+	items := validItems([]string{"a", "b", "a|b", "c|"})
+	if out, err := lstResult(options{}, items, []byte("a|b|c|"), nil); !reflect.DeepEqual(out, []string{"a", "b", "c|"}) || err != nil {
+		t.Errorf(`lstResult("a|b|c|", nil) = %q, %v`, out, err)
+	}
+	if out, err := lstResult(options{}, items, []byte("c||a"), nil); !reflect.DeepEqual(out, []string{"c|", "a"}) || err != nil {
+		t.Errorf(`lstResult("c||a", nil) = %q, %v`, out, err)
+	}
+	if out, err := lstResult(options{}, items, []byte("a|d"), nil); out != nil || err == nil {
+		t.Errorf(`lstResult("a|d", nil) = %q, %v`, out, err)
+	}
+}
+
+func Fuzz_splitValid(f *testing.F) {
+	const sep = "\x1e"
+	f.Add("a\nb\nc", uint64(5))
+	f.Add("a\nb\na\x1eb", uint64(4))
+	f.Add("\x1e\n\x1e\x1e\nx\x1e", uint64(7))
+	f.Add("\n\x1e", uint64(3))
+
+	f.Fuzz(func(t *testing.T, data string, mask uint64) {
+		items := strings.Split(data, "\n")
+		var selected []string
+		for i, item := range items {
+			if i < 64 && mask&(1<<i) != 0 {
+				selected = append(selected, item)
+			}
+		}
+		if len(selected) == 0 {
+			return
+		}
+		str := strings.Join(selected, sep)
+		valid := validItems(items)
+
+		res, ok := splitValid(str, sep, valid)
+		if !ok {
+			t.Fatalf("splitValid(%q) failed", str)
+		}
+		for _, r := range res {
+			if !valid(r) {
+				t.Errorf("splitValid(%q) = %q; %q is invalid", str, res, r)
+			}
+		}
+		if got := strings.Join(res, sep); got != str {
+			t.Errorf("splitValid(%q) = %q; joins to %q", str, res, got)
+		}
+		if !strings.Contains(data, sep) && !reflect.DeepEqual(res, selected) {
+			t.Errorf("splitValid(%q) = %q; want %q", str, res, selected)
+		}
+	})
+}
+
+func Test_pwdSecretResult(t *testing.T) {
+	username := options{username: true}
+	sentinel := errors.New("sentinel")
+	cancel := exit1Cmd().Run()
+	t.Parallel()
+
+	if usr, pwd, err := pwdSecretResult("|", options{}, []byte("out\n"), nil); usr != "" || string(pwd) != "out" || err != nil {
+		t.Errorf(`pwdSecretResult("out", nil) = %v, %q, %q`, usr, pwd, err)
+	}
+	out := []byte("one|two")
+	if usr, pwd, err := pwdSecretResult("|", username, out, nil); usr != "one" || string(pwd) != "two" || err != nil {
+		t.Errorf(`pwdSecretResult("one|two", nil) = %v, %q, %q`, usr, pwd, err)
+	}
+	if !bytes.Equal(out, make([]byte, len(out))) {
+		t.Errorf(`pwdSecretResult("one|two", nil) did not wipe %q`, out)
+	}
+	out = []byte("out")
+	if usr, pwd, err := pwdSecretResult("|", options{}, out, sentinel); usr != "" || pwd != nil || err != sentinel {
+		t.Errorf(`pwdSecretResult("out", error) = %v, %q, %q`, usr, pwd, err)
+	}
+	if !bytes.Equal(out, make([]byte, len(out))) {
+		t.Errorf(`pwdSecretResult("out", error) did not wipe %q`, out)
+	}
+	if usr, pwd, err := pwdSecretResult("|", options{}, []byte("out"), cancel); usr != "" || pwd != nil || err != ErrCanceled {
+		t.Errorf(`pwdSecretResult("out", cancel) = %v, %q, %q`, usr, pwd, err)
+	}
+}
+
 func exit1Cmd() *exec.Cmd {
 	if runtime.GOOS == "windows" {
 		return exec.Command("cmd", "/k", "exit", "1")
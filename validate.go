@@ -0,0 +1,93 @@
+package zenity
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate returns an Option to validate the text entered in
+// Entry, or the password entered in Password.
+//
+// If validate returns an error, the dialog is shown again,
+// until valid input is entered, or the dialog is canceled.
+// For Entry, the error is prepended to the prompt,
+// and the previous text is kept.
+// For Password, the error is shown in a separate dialog.
+func Validate(validate func(text string) error) Option {
+	return funcOption(func(o *options) { o.validate = validate })
+}
+
+// ValidateNonEmpty validates that text is not empty, or only whitespace.
+func ValidateNonEmpty(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New("a value is required")
+	}
+	return nil
+}
+
+// ValidateRegexp returns a validator that checks that text matches re.
+// Use anchors, like ^ and $, to match the whole text.
+func ValidateRegexp(re *regexp.Regexp) func(text string) error {
+	return func(text string) error {
+		if !re.MatchString(text) {
+			return fmt.Errorf("value must match %s", re)
+		}
+		return nil
+	}
+}
+
+// ValidateLength returns a validator that checks that the length of text,
+// in characters, is between min and max, inclusive.
+// A max of zero, or less, means no maximum.
+func ValidateLength(min, max int) func(text string) error {
+	return func(text string) error {
+		n := utf8.RuneCountInString(text)
+		switch {
+		case n < min && max > 0:
+			return fmt.Errorf("value must have between %d and %d characters", min, max)
+		case n < min:
+			return fmt.Errorf("value must have at least %d characters", min)
+		case n > max && max > 0:
+			return fmt.Errorf("value must have at most %d characters", max)
+		}
+		return nil
+	}
+}
+
+// ValidateEmail validates that text is an e-mail address,
+// like user@example.com, without a display name.
+func ValidateEmail(text string) error {
+	addr, err := mail.ParseAddress(text)
+	if err != nil || addr.Name != "" || addr.Address != text {
+		return errors.New("value must be an e-mail address")
+	}
+	return nil
+}
+
+// ValidateURL validates that text is an absolute URL, with a host.
+func ValidateURL(text string) error {
+	u, err := url.Parse(text)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return errors.New("value must be a URL")
+	}
+	return nil
+}
+
+// ValidateHostPort validates that text is a host and port,
+// like example.com:80 or [::1]:80.
+func ValidateHostPort(text string) error {
+	host, port, err := net.SplitHostPort(text)
+	if err == nil && host != "" {
+		if _, err = strconv.ParseUint(port, 10, 16); err == nil {
+			return nil
+		}
+	}
+	return errors.New("value must be a host:port")
+}
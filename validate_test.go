@@ -0,0 +1,48 @@
+package zenity
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		validate func(string) error
+		text     string
+		valid    bool
+	}{
+		{"NonEmpty", ValidateNonEmpty, "text", true},
+		{"NonEmpty", ValidateNonEmpty, "", false},
+		{"NonEmpty", ValidateNonEmpty, " \t", false},
+		{"Regexp", ValidateRegexp(regexp.MustCompile(`^\d+$`)), "123", true},
+		{"Regexp", ValidateRegexp(regexp.MustCompile(`^\d+$`)), "12a", false},
+		{"Length", ValidateLength(2, 3), "ab", true},
+		{"Length", ValidateLength(2, 3), "αβγ", true},
+		{"Length", ValidateLength(2, 3), "a", false},
+		{"Length", ValidateLength(2, 3), "abcd", false},
+		{"Length", ValidateLength(2, 0), "abcdef", true},
+		{"Length", ValidateLength(2, 0), "a", false},
+		{"Email", ValidateEmail, "user@example.com", true},
+		{"Email", ValidateEmail, "User <user@example.com>", false},
+		{"Email", ValidateEmail, "user", false},
+		{"Email", ValidateEmail, "", false},
+		{"URL", ValidateURL, "https://example.com/path", true},
+		{"URL", ValidateURL, "example.com", false},
+		{"URL", ValidateURL, "/path", false},
+		{"URL", ValidateURL, "http://[::1", false},
+		{"HostPort", ValidateHostPort, "example.com:80", true},
+		{"HostPort", ValidateHostPort, "[::1]:8080", true},
+		{"HostPort", ValidateHostPort, "example.com", false},
+		{"HostPort", ValidateHostPort, ":80", false},
+		{"HostPort", ValidateHostPort, "example.com:http", false},
+		{"HostPort", ValidateHostPort, "example.com:65536", false},
+	}
+	for _, tt := range tests {
+		err := tt.validate(tt.text)
+		if (err == nil) != tt.valid {
+			t.Errorf("Validate%s(%q) = %v; want valid %v", tt.name, tt.text, err, tt.valid)
+		}
+	}
+}
@@ -30,6 +30,14 @@ const ErrExtraButton = zenutil.ErrExtraButton
 // ErrUnsupported is returned when a combination of options is not supported.
 const ErrUnsupported = zenutil.ErrUnsupported
 
+// ErrFilterMismatch is returned when a selected file does not match the
+// file filters, and filters are enforced.
+const ErrFilterMismatch = zenutil.ErrFilterMismatch
+
+// ErrNoCredentials is returned by a CredentialStore when no credentials
+// are stored for a service.
+const ErrNoCredentials = zenutil.ErrNoCredentials
+
 // IsAvailable reports whether dependencies of the package are installed.
 // It always returns true on Windows and macOS.
 func IsAvailable() bool {
@@ -61,6 +69,23 @@ type options struct {
 	entryText string
 	hideText  bool
 	username  bool
+	validate  func(string) error
+	history   *string
+	choices   []string
+
+	// Password options
+	confirmPassword     bool
+	passwordPolicy      *PasswordPolicy
+	rememberCredentials *string
+	credentialStore     CredentialStore
+	rememberText        string
+	rememberLabel       string
+	notNowLabel         string
+
+	// Prompt options
+	promptMin     any
+	promptMax     any
+	promptDefault any
 
 	// List options
 	listKind      listKind
@@ -69,7 +94,13 @@ type options struct {
 	defaultItems  []string
 
 	// Calendar options
-	time *time.Time
+	time      *time.Time
+	minDate   *time.Time
+	maxDate   *time.Time
+	weekdays  bool
+	timeZones []string
+	timeLabel string
+	zoneLabel string
 
 	// File selection options
 	directory        bool
@@ -77,7 +108,16 @@ type options struct {
 	confirmCreate    bool
 	showHidden       bool
 	filename         string
+	expandFilename   bool
 	fileFilters      FileFilters
+	enforceFilters   bool
+	autoExtension    bool
+	validatePath     func(string) error
+	rememberLocation *string
+	showErrors       bool
+	pathStyle        PathStyle
+	documentPortal   bool
+	recent           bool
 
 	// Color selection options
 	color       color.Color
@@ -88,6 +128,8 @@ type options struct {
 	noCancel      bool
 	autoClose     bool
 	timeRemaining bool
+	keepOpen      bool
+	concurrency   int
 
 	// Context for timeout
 	ctx context.Context
@@ -110,6 +152,21 @@ func applyOptions(options []Option) (res options) {
 	return
 }
 
+// generalOptions returns the general options of opts,
+// for use by dialogs shown on behalf of another dialog.
+func generalOptions(opts options) options {
+	return options{
+		title:      opts.title,
+		windowIcon: opts.windowIcon,
+		attach:     opts.attach,
+		modal:      opts.modal,
+		display:    opts.display,
+		class:      opts.class,
+		name:       opts.name,
+		ctx:        opts.ctx,
+	}
+}
+
 // Title returns an Option to set the dialog title.
 func Title(title string) Option {
 	return funcOption(func(o *options) { o.title = &title })
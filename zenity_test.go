@@ -43,6 +43,15 @@ func Test_applyOptions(t *testing.T) {
 		{name: "EntryText", args: EntryText("text"), want: options{entryText: "text"}},
 		{name: "HideText", args: HideText(), want: options{hideText: true}},
 		{name: "Username", args: Username(), want: options{username: true}},
+		{name: "History", args: History("key"), want: options{history: ptr("key")}},
+		{name: "ConfirmPassword", args: ConfirmPassword(), want: options{confirmPassword: true}},
+		{name: "PasswordPolicy", args: PasswordPolicy{MinLength: 8}, want: options{passwordPolicy: &PasswordPolicy{MinLength: 8}}},
+		{name: "RememberCredentials", args: RememberCredentials("service"), want: options{rememberCredentials: ptr("service")}},
+		{name: "Credentials", args: Credentials(FileCredentialStore{}), want: options{credentialStore: FileCredentialStore{}}},
+		{name: "RememberLabels", args: RememberLabels("Save?", "Save", "Skip"), want: options{rememberText: "Save?", rememberLabel: "Save", notNowLabel: "Skip"}},
+		{name: "PromptMin", args: PromptMin(1), want: options{promptMin: 1}},
+		{name: "PromptMax", args: PromptMax(1.5), want: options{promptMax: 1.5}},
+		{name: "PromptDefault", args: PromptDefault(time.Second), want: options{promptDefault: time.Second}},
 
 		// List options
 		{name: "CheckList", args: CheckList(), want: options{listKind: checkListKind}},
@@ -53,6 +62,11 @@ func Test_applyOptions(t *testing.T) {
 
 		// Calendar options
 		{name: "DefaultDate", args: DefaultDate(2006, time.January, 1), want: options{time: &date}},
+		{name: "MinDate", args: MinDate(2006, time.January, 1), want: options{minDate: &date}},
+		{name: "MaxDate", args: MaxDate(2006, time.January, 1), want: options{maxDate: &date}},
+		{name: "Weekdays", args: Weekdays(), want: options{weekdays: true}},
+		{name: "DateTimeLabels", args: DateTimeLabels("Hora:", "Fuso:"), want: options{timeLabel: "Hora:", zoneLabel: "Fuso:"}},
+		{name: "TimeZones", args: TimeZones("UTC", "Europe/Lisbon"), want: options{timeZones: []string{"UTC", "Europe/Lisbon"}}},
 
 		// File selection options
 		{name: "Directory", args: Directory(), want: options{directory: true}},
@@ -60,6 +74,7 @@ func Test_applyOptions(t *testing.T) {
 		{name: "ConfirmCreate", args: ConfirmCreate(), want: options{confirmCreate: true}},
 		{name: "ShowHidden", args: ShowHidden(), want: options{showHidden: true}},
 		{name: "Filename", args: Filename("file.go"), want: options{filename: "file.go"}},
+		{name: "ExpandFilename", args: ExpandFilename(), want: options{expandFilename: true}},
 		{name: "FileFilter", args: FileFilter{"All files", []string{"*.*"}, true}, want: options{
 			fileFilters: FileFilters{{"All files", []string{"*.*"}, true}},
 		}},
@@ -67,6 +82,15 @@ func Test_applyOptions(t *testing.T) {
 			fileFilters: FileFilters{{"Go files", []string{"*.go"}, true}},
 		}},
 
+		{name: "EnforceFilters", args: EnforceFilters(), want: options{enforceFilters: true}},
+		{name: "AutoExtension", args: AutoExtension(), want: options{autoExtension: true}},
+		{name: "RememberLocation", args: RememberLocation("key"), want: options{rememberLocation: ptr("key")}},
+		{name: "ShowErrors", args: ShowErrors(), want: options{showErrors: true}},
+		{name: "WSLPaths", args: WSLPaths{Distro: "Ubuntu"}, want: options{pathStyle: WSLPaths{Distro: "Ubuntu"}}},
+		{name: "DocumentPortal", args: DocumentPortal(), want: options{documentPortal: true}},
+		{name: "Recent", args: Recent(), want: options{recent: true}},
+		{name: "CygwinPaths", args: CygwinPaths{Prefix: "/"}, want: options{pathStyle: CygwinPaths{Prefix: "/"}}},
+
 		// Color selection options
 		{name: "Color", args: Color(color.Black), want: options{color: color.Black}},
 		{name: "ShowPalette", args: ShowPalette(), want: options{showPalette: true}},
@@ -76,6 +100,8 @@ func Test_applyOptions(t *testing.T) {
 		{name: "Pulsate", args: Pulsate(), want: options{maxValue: -1}},
 		{name: "NoCancel", args: NoCancel(), want: options{noCancel: true}},
 		{name: "TimeRemaining", args: TimeRemaining(), want: options{timeRemaining: true}},
+		{name: "KeepOpen", args: KeepOpen(), want: options{keepOpen: true}},
+		{name: "Concurrency", args: Concurrency(4), want: options{concurrency: 4}},
 
 		// Context for timeout
 		{name: "Context", args: Context(context.TODO()), want: options{ctx: context.TODO()}},
@@ -0,0 +1,23 @@
+//go:build !windows && !darwin
+
+package zenity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Install script as a fake dialog tool, ahead of the real ones in PATH,
+// and return the directory it is installed in.
+func installFakeTool(t *testing.T, script string) string {
+	dir := t.TempDir()
+	for _, name := range []string{"qarma", "zenity", "matedialog"} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return dir
+}